@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+type ClipboardData struct {
+	Success bool   `json:"success"`
+	Text    string `json:"text,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func sendClipboardEvent(componentID string, event string, data ClipboardData) {
+	jsonBytes, _ := json.Marshal(&data)
+	sendEvent(componentID, event, string(jsonBytes))
+}
+
+func clipboard() js.Value {
+	return js.Global().Get("navigator").Get("clipboard")
+}
+
+// CopyToClipboard write text in the clipboard and send ClipboardWriteResult to componentID
+func CopyToClipboard(componentID string, text string) {
+	if clipboard().IsUndefined() {
+		sendClipboardEvent(componentID, "ClipboardWriteResult", ClipboardData{Error: "clipboard is not supported"})
+		return
+	}
+	clipboard().Call("writeText", text).Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		sendClipboardEvent(componentID, "ClipboardWriteResult", ClipboardData{Success: true})
+		return nil
+	}), js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		sendClipboardEvent(componentID, "ClipboardWriteResult", ClipboardData{Error: args[0].Call("toString").String()})
+		return nil
+	}))
+}
+
+// ReadFromClipboard read the text of clipboard and send it as eventName to componentID
+func ReadFromClipboard(componentID string, eventName string) {
+	if clipboard().IsUndefined() {
+		sendClipboardEvent(componentID, eventName, ClipboardData{Error: "clipboard is not supported"})
+		return
+	}
+	clipboard().Call("readText").Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		sendClipboardEvent(componentID, eventName, ClipboardData{Success: true, Text: args[0].String()})
+		return nil
+	}), js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		sendClipboardEvent(componentID, eventName, ClipboardData{Error: args[0].Call("toString").String()})
+		return nil
+	}))
+}
+
+func initClipboard() {
+	js.Global().Set("ReadFromClipboard", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		ReadFromClipboard(args[0].String(), args[1].String())
+		return nil
+	}))
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+type DropFileData struct {
+	Name    string `json:"name"`
+	Size    int    `json:"size"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// initFileDropZone send data-drop-event to data-drop-component with the content of dropped files
+func initFileDropZone() {
+	zone := func(event js.Value) js.Value {
+		target := event.Get("target")
+		if target.Get("closest").IsUndefined() {
+			return js.Null()
+		}
+		return target.Call("closest", "[data-drop-component][data-drop-event]")
+	}
+	highlight := func(element js.Value, on bool) {
+		class := element.Call("getAttribute", "data-drop-highlight-class")
+		if class.IsNull() {
+			return
+		}
+		element.Get("classList").Call("toggle", class.String(), on)
+	}
+
+	document.Call("addEventListener", "dragover", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !zone(args[0]).IsNull() {
+			args[0].Call("preventDefault")
+		}
+		return nil
+	}))
+
+	document.Call("addEventListener", "dragenter", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if element := zone(args[0]); !element.IsNull() {
+			args[0].Call("preventDefault")
+			highlight(element, true)
+		}
+		return nil
+	}))
+
+	document.Call("addEventListener", "dragleave", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		element := zone(args[0])
+		if element.IsNull() {
+			return nil
+		}
+		related := args[0].Get("relatedTarget")
+		if related.IsNull() || !element.Call("contains", related).Bool() {
+			highlight(element, false)
+		}
+		return nil
+	}))
+
+	document.Call("addEventListener", "drop", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		element := zone(args[0])
+		if element.IsNull() {
+			return nil
+		}
+		args[0].Call("preventDefault")
+		highlight(element, false)
+		componentID := element.Call("getAttribute", "data-drop-component").String()
+		event := element.Call("getAttribute", "data-drop-event").String()
+		files := args[0].Get("dataTransfer").Get("files")
+		for i := 0; i < files.Length(); i++ {
+			file := files.Index(i)
+			reader := js.Global().Get("FileReader").New()
+			var onload js.Func
+			onload = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+				defer onload.Release()
+				data := DropFileData{
+					Name:    file.Get("name").String(),
+					Size:    file.Get("size").Int(),
+					Type:    file.Get("type").String(),
+					Content: reader.Get("result").String(),
+				}
+				jsonBytes, _ := json.Marshal(&data)
+				sendEvent(componentID, event, string(jsonBytes))
+				return nil
+			})
+			reader.Set("onload", onload)
+			reader.Call("readAsText", file)
+		}
+		return nil
+	}))
+}
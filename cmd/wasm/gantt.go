@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"syscall/js"
+)
+
+type GanttDragData struct {
+	ID string  `json:"id"`
+	DX float64 `json:"dx"`
+}
+
+// initGanttDrag drag the bars [data-gantt-bar] of svg[data-gantt-component], the handle .gantt-handle resize the bar.
+// It send TaskMove or TaskResize with the id of task and the delta in px when the drag stops
+func initGanttDrag() {
+	var (
+		bar    js.Value = js.Null()
+		resize bool
+		startX float64
+		dx     float64
+		width  float64
+	)
+
+	document.Call("addEventListener", "mousedown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		target := args[0].Get("target")
+		if target.Get("closest").IsUndefined() {
+			return nil
+		}
+		element := target.Call("closest", "[data-gantt-bar]")
+		if element.IsNull() || target.Call("closest", "svg[data-gantt-component]").IsNull() {
+			return nil
+		}
+		args[0].Call("preventDefault")
+		args[0].Call("stopPropagation")
+		bar = element
+		resize = target.Get("classList").Call("contains", "gantt-handle").Bool()
+		startX, dx = args[0].Get("clientX").Float(), 0
+		width, _ = strconv.ParseFloat(bar.Call("querySelector", "rect").Call("getAttribute", "width").String(), 64)
+		return nil
+	}))
+
+	document.Call("addEventListener", "mousemove", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if bar.IsNull() {
+			return nil
+		}
+		dx = args[0].Get("clientX").Float() - startX
+		if resize {
+			if width+dx < 2 {
+				dx = 2 - width
+			}
+			bar.Call("querySelector", "rect").Call("setAttribute", "width", fmt.Sprintf("%g", width+dx))
+		} else {
+			bar.Call("setAttribute", "transform", fmt.Sprintf("translate(%g 0)", dx))
+		}
+		return nil
+	}))
+
+	document.Call("addEventListener", "mouseup", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if bar.IsNull() {
+			return nil
+		}
+		element := bar
+		bar = js.Null()
+		if dx == 0 {
+			return nil
+		}
+		componentID := element.Call("closest", "svg[data-gantt-component]").Call("getAttribute", "data-gantt-component").String()
+		data := GanttDragData{ID: element.Call("getAttribute", "data-gantt-bar").String(), DX: dx}
+		event := "TaskMove"
+		if resize {
+			event = "TaskResize"
+		}
+		jsonBytes, _ := json.Marshal(&data)
+		sendEvent(componentID, event, string(jsonBytes))
+		return nil
+	}))
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+type GeolocationData struct {
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Accuracy float64 `json:"accuracy"`
+	Altitude float64 `json:"altitude"`
+}
+
+type GeolocationErrorData struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// geolocationCallbacks return the callbacks of getCurrentPosition/watchPosition that send the events to componentID
+func geolocationCallbacks(componentID string) (js.Func, js.Func) {
+	success := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		coords := args[0].Get("coords")
+		data := GeolocationData{
+			Lat:      coords.Get("latitude").Float(),
+			Lon:      coords.Get("longitude").Float(),
+			Accuracy: coords.Get("accuracy").Float(),
+		}
+		if altitude := coords.Get("altitude"); altitude.Type() == js.TypeNumber {
+			data.Altitude = altitude.Float()
+		}
+		jsonBytes, _ := json.Marshal(&data)
+		sendEvent(componentID, "Geolocation", string(jsonBytes))
+		return nil
+	})
+	failure := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		data := GeolocationErrorData{
+			Code:    args[0].Get("code").Int(),
+			Message: args[0].Get("message").String(),
+		}
+		jsonBytes, _ := json.Marshal(&data)
+		sendEvent(componentID, "GeolocationError", string(jsonBytes))
+		return nil
+	})
+	return success, failure
+}
+
+func initGeolocation() {
+	geolocation := js.Global().Get("navigator").Get("geolocation")
+
+	js.Global().Set("RequestGeolocation", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		componentID := args[0].String()
+		if geolocation.IsUndefined() {
+			sendEvent(componentID, "GeolocationError", `{"code":0,"message":"geolocation is not supported"}`)
+			return nil
+		}
+		success, failure := geolocationCallbacks(componentID)
+		geolocation.Call("getCurrentPosition", success, failure)
+		return nil
+	}))
+
+	js.Global().Set("RequestGeolocationWatch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		componentID := args[0].String()
+		if geolocation.IsUndefined() {
+			sendEvent(componentID, "GeolocationError", `{"code":0,"message":"geolocation is not supported"}`)
+			return -1
+		}
+		success, failure := geolocationCallbacks(componentID)
+		return geolocation.Call("watchPosition", success, failure).Int()
+	}))
+
+	js.Global().Set("ClearGeolocationWatch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !geolocation.IsUndefined() {
+			geolocation.Call("clearWatch", args[0].Int())
+		}
+		return nil
+	}))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"syscall/js"
+)
+
+// PushHistoryState add an entry to the browser history, when the user goes back to it eventName is sent to componentID
+// with state (JSON) as data. With replace the current entry is replaced
+func PushHistoryState(componentID string, eventName string, state string, url string, replace bool) {
+	method := "pushState"
+	if replace {
+		method = "replaceState"
+	}
+	entry := map[string]interface{}{"liveview": true, "componentID": componentID, "eventName": eventName, "state": state}
+	if url == "" {
+		window.Get("history").Call(method, entry, "")
+		return
+	}
+	window.Get("history").Call(method, entry, "", url)
+}
+
+// initHistory send the event of entry of history on popstate (back/forward buttons)
+func initHistory() {
+	window.Call("addEventListener", "popstate", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		entry := args[0].Get("state")
+		if entry.IsNull() || entry.IsUndefined() || !entry.Get("liveview").Truthy() {
+			return nil
+		}
+		sendEvent(entry.Get("componentID").String(), entry.Get("eventName").String(), entry.Get("state").String())
+		return nil
+	}))
+	liveview := js.Global().Get("liveview")
+	if liveview.IsUndefined() {
+		liveview = js.Global().Get("Object").New()
+		js.Global().Set("liveview", liveview)
+	}
+	liveview.Set("pushHistoryState", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		url := ""
+		if len(args) > 3 {
+			url = args[3].String()
+		}
+		PushHistoryState(args[0].String(), args[1].String(), js.Global().Get("JSON").Call("stringify", args[2]).String(), url, false)
+		return nil
+	}))
+}
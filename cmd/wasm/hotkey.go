@@ -0,0 +1,90 @@
+package main
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+// normalizeHotkey return the keys in order ctrl+meta+alt+shift+key and lower case ("Meta+K" -> "meta+k")
+func normalizeHotkey(hotkey string) string {
+	parts := strings.Split(strings.ToLower(strings.ReplaceAll(hotkey, " ", "")), "+")
+	modifiers := map[string]bool{}
+	key := ""
+	for _, p := range parts {
+		switch p {
+		case "ctrl", "control":
+			modifiers["ctrl"] = true
+		case "meta", "cmd", "command":
+			modifiers["meta"] = true
+		case "alt", "option":
+			modifiers["alt"] = true
+		case "shift":
+			modifiers["shift"] = true
+		default:
+			key = p
+		}
+	}
+	result := ""
+	for _, m := range []string{"ctrl", "meta", "alt", "shift"} {
+		if modifiers[m] {
+			result += m + "+"
+		}
+	}
+	return result + key
+}
+
+func eventHotkey(event js.Value) string {
+	hotkey := ""
+	if event.Get("ctrlKey").Bool() {
+		hotkey += "ctrl+"
+	}
+	if event.Get("metaKey").Bool() {
+		hotkey += "meta+"
+	}
+	if event.Get("altKey").Bool() {
+		hotkey += "alt+"
+	}
+	if event.Get("shiftKey").Bool() {
+		hotkey += "shift+"
+	}
+	return hotkey + strings.ToLower(event.Get("key").String())
+}
+
+// isEditable return true if the element is input, textarea or [contenteditable]
+func isEditable(element js.Value) bool {
+	if element.IsNull() || element.IsUndefined() || element.Get("tagName").IsUndefined() {
+		return false
+	}
+	tag := element.Get("tagName").String()
+	return tag == "INPUT" || tag == "TEXTAREA" || tag == "SELECT" || element.Get("isContentEditable").Truthy()
+}
+
+// initHotkeys send data-hotkey-event to data-hotkey-component when the keys of data-hotkey are pressed,
+// the data is data-hotkey-data or the keys. With the focus in inputs only the elements with data-hotkey-global are sent
+func initHotkeys() {
+	document.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		if event.Get("key").IsUndefined() {
+			return nil
+		}
+		hotkey := eventHotkey(event)
+		editing := isEditable(event.Get("target"))
+		elements := document.Call("querySelectorAll", "[data-hotkey][data-hotkey-component][data-hotkey-event]")
+		for i := 0; i < elements.Length(); i++ {
+			element := elements.Index(i)
+			if normalizeHotkey(element.Call("getAttribute", "data-hotkey").String()) != hotkey {
+				continue
+			}
+			if editing && !element.Call("hasAttribute", "data-hotkey-global").Bool() {
+				continue
+			}
+			event.Call("preventDefault")
+			data := hotkey
+			if element.Call("hasAttribute", "data-hotkey-data").Bool() {
+				data = element.Call("getAttribute", "data-hotkey-data").String()
+			}
+			sendEvent(element.Call("getAttribute", "data-hotkey-component").String(), element.Call("getAttribute", "data-hotkey-event").String(), data)
+		}
+		return nil
+	}))
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"syscall/js"
+)
+
+type IntersectData struct {
+	ElementID string  `json:"elementId"`
+	Ratio     float64 `json:"ratio"`
+}
+
+var intersectionObservers = make(map[float64]js.Value)
+
+// initIntersectionObserver send data-intersect-event to data-intersect-component when the element enter in the viewport
+func initIntersectionObserver() {
+	observeIntersectElements()
+}
+
+func intersectionObserver(threshold float64) js.Value {
+	if observer, ok := intersectionObservers[threshold]; ok {
+		return observer
+	}
+	observer := js.Global().Get("IntersectionObserver").New(js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		entries := args[0]
+		for i := 0; i < entries.Length(); i++ {
+			entry := entries.Index(i)
+			target := entry.Get("target")
+			componentID := target.Call("getAttribute", "data-intersect-component").String()
+			event := target.Call("getAttribute", "data-intersect-event").String()
+			data := IntersectData{ElementID: target.Get("id").String(), Ratio: entry.Get("intersectionRatio").Float()}
+			jsonBytes, _ := json.Marshal(&data)
+			inside := target.Call("getAttribute", "data-intersect-inside").Truthy()
+
+			if entry.Get("isIntersecting").Bool() && data.Ratio >= threshold {
+				if inside {
+					continue
+				}
+				target.Call("setAttribute", "data-intersect-inside", "true")
+				sendEvent(componentID, event, string(jsonBytes))
+				if target.Call("getAttribute", "data-intersect-once").String() == "true" {
+					args[1].Call("unobserve", target)
+				}
+				continue
+			}
+			if inside {
+				target.Call("removeAttribute", "data-intersect-inside")
+				if target.Call("getAttribute", "data-intersect-bidirectional").String() == "true" {
+					sendEvent(componentID, event+"Exit", string(jsonBytes))
+				}
+			}
+		}
+		return nil
+	}), map[string]interface{}{"threshold": threshold})
+	intersectionObservers[threshold] = observer
+	return observer
+}
+
+// observeIntersectElements observe the new elements with data-intersect-component, it is called after each fill
+func observeIntersectElements() {
+	if js.Global().Get("IntersectionObserver").IsUndefined() {
+		return
+	}
+	elements := document.Call("querySelectorAll", "[data-intersect-component][data-intersect-event]:not([data-intersect-observed])")
+	for i := 0; i < elements.Length(); i++ {
+		element := elements.Index(i)
+		threshold := 0.0
+		if value := element.Call("getAttribute", "data-intersect-threshold"); !value.IsNull() {
+			if t, err := strconv.ParseFloat(value.String(), 64); err == nil {
+				threshold = t
+			}
+		}
+		element.Call("setAttribute", "data-intersect-observed", "true")
+		intersectionObserver(threshold).Call("observe", element)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"syscall/js"
+	"time"
 )
 
 var (
@@ -18,10 +19,13 @@ var (
 	window    js.Value = js.Global().Get("window")
 	console   js.Value = js.Global().Get("console")
 	webSocket js.Value = js.Global().Get("WebSocket")
-	loc       js.Value = window.Get("location")
-	uri       string   = "ws:"
+	loc       js.Value
+	uri       string = "ws:"
 	ws        js.Value
-	protocol  string = loc.Get("protocol").String()
+	protocol  string
+	connected bool
+	// session identify the page in the server across reconnects, the server keeps its messages for the replay
+	session string
 )
 
 type MsgEvent struct {
@@ -32,12 +36,17 @@ type MsgEvent struct {
 }
 
 type DataEventIn struct {
-	ID        string      `json:"id"`
-	IdRet     string      `json:"id_ret"`
-	Type      string      `json:"type"`
-	Value     interface{} `json:"value"`
-	Propertie string      `json:"propertie"`
-	SubType   string      `json:"sub_type"`
+	ID        string        `json:"id"`
+	IdRet     string        `json:"id_ret"`
+	Type      string        `json:"type"`
+	Value     interface{}   `json:"value"`
+	Propertie string        `json:"propertie"`
+	SubType   string        `json:"sub_type"`
+	Messages  []DataEventIn `json:"messages"`
+	Filename  string        `json:"filename"`
+	Mime      string        `json:"mime"`
+	Event     string        `json:"event"`
+	URL       string        `json:"url"`
 }
 
 type DataEventOut struct {
@@ -61,12 +70,23 @@ func connect() {
 	}
 	fmt.Println("protocol: " + protocol + " uri: " + uri)
 	uri += "//" + loc.Get("host").String()
-	uri += loc.Get("pathname").String() + "ws_goliveview"
+	uri += loc.Get("pathname").String() + "ws_goliveview?session=" + session
 	ws = webSocket.New(uri)
 
 	handlerOnOpen := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		fmt.Println(ws.Get("readyState").Int())
 		fmt.Println("Connected...ok!!")
+		hideOfflineBanner()
+		csrf := document.Call("querySelector", `meta[name="csrf-token"]`)
+		if !csrf.IsNull() {
+			jsonBytes, _ := json.Marshal(map[string]string{"type": "csrf", "token": csrf.Call("getAttribute", "content").String()})
+			ws.Call("send", string(jsonBytes))
+		}
+		if connected {
+			jsonBytes, _ := json.Marshal(map[string]string{"type": "reconnect", "session": session})
+			ws.Call("send", string(jsonBytes))
+		}
+		connected = true
 		return nil
 	})
 
@@ -78,6 +98,7 @@ func connect() {
 		}()
 		fmt.Println(ws)
 		fmt.Println("Disconnected...ok")
+		showOfflineBanner()
 		document.Call("getElementById", "content").Set("innerHTML", "Disconnected")
 		return nil
 	})
@@ -86,78 +107,7 @@ func connect() {
 		evtData := args[0].Get("data").String()
 		var dataEventIn DataEventIn
 		json.Unmarshal([]byte(evtData), &dataEventIn)
-		currentElement := document.Call("getElementById", dataEventIn.ID)
-
-		if currentElement.IsNull() {
-			return nil
-		}
-
-		if dataEventIn.Type == "fill" {
-			fmt.Println("fill")
-			currentElement.Set("innerHTML", dataEventIn.Value)
-			return nil
-		}
-
-		if dataEventIn.Type == "remove" {
-			currentElement.Call("remove")
-		}
-
-		if dataEventIn.Type == "addNode" {
-			var d = document.Call("createElement", "div")
-			currentElement.Set("innerHTML", fmt.Sprint(dataEventIn.Value))
-			currentElement.Call("appendChild", d)
-		}
-
-		if dataEventIn.Type == "text" {
-			if dataEventIn.Value != "" {
-				currentElement.Set("innerText", dataEventIn.Value)
-			}
-		}
-
-		if dataEventIn.Type == "style" {
-			currentElement.Get("style").Set("cssText", dataEventIn.Value)
-		}
-
-		if dataEventIn.Type == "set" {
-			currentElement.Set("value", dataEventIn.Value)
-		}
-
-		if dataEventIn.Type == "script" {
-			currentElement.Call("eval", dataEventIn.Value)
-		}
-
-		if dataEventIn.Type == "propertie" {
-			currentElement.Set(dataEventIn.Propertie, dataEventIn.Value)
-		}
-
-		if dataEventIn.Type == "get" {
-			dataEventOut := DataEventOut{}
-			dataEventOut.Type = "get"
-			dataEventOut.IdRet = dataEventIn.IdRet
-			if dataEventIn.SubType == "value" {
-				value := currentElement.Get("value")
-				dataEventOut.Data = GetValue(value)
-			}
-			if dataEventIn.SubType == "html" {
-				value := currentElement.Get("innerHTML")
-				dataEventOut.Data = GetValue(value)
-			}
-			if dataEventIn.SubType == "text" {
-				value := currentElement.Get("innerText")
-				dataEventOut.Data = GetValue(value)
-			}
-			if dataEventIn.SubType == "style" {
-				value := currentElement.Get("style").Get(fmt.Sprint(dataEventIn.Value))
-				dataEventOut.Data = GetValue(value)
-			}
-
-			if dataEventIn.SubType == "propertie" {
-				prop := currentElement.Get(fmt.Sprint(dataEventIn.Value))
-				dataEventOut.Data = GetValue(prop)
-			}
-			jsonBytes, _ := json.Marshal(&dataEventOut)
-			ws.Call("send", string(jsonBytes))
-		}
+		handleMessage(dataEventIn)
 		return nil
 	})
 
@@ -170,11 +120,18 @@ func connect() {
 }
 
 func main() {
+	if document.IsUndefined() {
+		// json.wasm loaded by worker.js in a Web Worker
+		runWorker()
+		return
+	}
 	document.Call("getElementById", "content").Set("innerHTML", "Disconnected")
+	session = newSessionID()
 	connect()
 
 	js.Global().Call("setInterval", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		if ws.Get("readyState").Int() != 1 {
+		if ws.Get("readyState").Int() != 1 && time.Now().After(reconnectAfter) {
+			showOfflineBanner()
 			connect()
 		}
 		return nil
@@ -196,9 +153,231 @@ func main() {
 		sendEvent(id, event, data)
 		return nil
 	}))
+	initGeolocation()
+	initResizeObserver()
+	initIntersectionObserver()
+	registerServiceWorker()
+	initNotification()
+	initFileDropZone()
+	initClipboard()
+	initHotkeys()
+	initPanZoom()
+	initSplitPanels()
+	initVirtualScroll()
+	initGanttDrag()
+	initShutdown()
+	initWorker()
+	initHistory()
+	initVideo()
+	initSlider()
+	initOTP()
 	<-make(chan struct{})
 }
 
+// newSessionID return a random id for the page
+func newSessionID() string {
+	crypto := js.Global().Get("crypto")
+	if !crypto.IsUndefined() && !crypto.Get("randomUUID").IsUndefined() {
+		return crypto.Call("randomUUID").String()
+	}
+	bytes := js.Global().Get("Uint8Array").New(16)
+	crypto.Call("getRandomValues", bytes)
+	id := ""
+	for i := 0; i < 16; i++ {
+		id += fmt.Sprintf("%02x", bytes.Index(i).Int())
+	}
+	return id
+}
+
+func handleMessage(dataEventIn DataEventIn) {
+	if dataEventIn.Type == "replay" {
+		// the messages are in the order they were sent, only the last fill of each id is applied
+		lastFill := make(map[string]int)
+		for i, m := range dataEventIn.Messages {
+			if m.Type == "fill" {
+				lastFill[m.ID] = i
+			}
+		}
+		for i, m := range dataEventIn.Messages {
+			if m.Type == "fill" && lastFill[m.ID] != i {
+				continue
+			}
+			handleMessage(m)
+		}
+		return
+	}
+
+	if dataEventIn.Type == "script" {
+		js.Global().Call("eval", dataEventIn.Value)
+		return
+	}
+
+	if dataEventIn.Type == "notification" {
+		ShowNotification(dataEventIn.ID, dataEventIn.Value)
+		return
+	}
+
+	if dataEventIn.Type == "get" && dataEventIn.SubType == "notification_permission" {
+		sendGet(dataEventIn.IdRet, notificationPermission())
+		return
+	}
+
+	if dataEventIn.Type == "clipboard_write" {
+		CopyToClipboard(dataEventIn.ID, fmt.Sprint(dataEventIn.Value))
+		return
+	}
+
+	if dataEventIn.Type == "redirect" {
+		loc.Set("href", dataEventIn.Value)
+		return
+	}
+
+	if dataEventIn.Type == "history_push" || dataEventIn.Type == "history_replace" {
+		PushHistoryState(dataEventIn.ID, dataEventIn.Event, fmt.Sprint(dataEventIn.Value), dataEventIn.URL, dataEventIn.Type == "history_replace")
+		return
+	}
+
+	if dataEventIn.Type == "open_tab" {
+		window.Call("open", dataEventIn.Value, "_blank")
+		return
+	}
+
+	if dataEventIn.Type == "download" {
+		a := document.Call("createElement", "a")
+		uri := "data:" + dataEventIn.Mime + ";charset=utf-8," + js.Global().Call("encodeURIComponent", fmt.Sprint(dataEventIn.Value)).String()
+		a.Set("href", uri)
+		a.Set("download", dataEventIn.Filename)
+		document.Get("body").Call("appendChild", a)
+		a.Call("click")
+		a.Call("remove")
+		return
+	}
+
+	currentElement := document.Call("getElementById", dataEventIn.ID)
+
+	if currentElement.IsNull() {
+		return
+	}
+
+	if dataEventIn.Type == "fill" {
+		fmt.Println("fill")
+		currentElement.Set("innerHTML", dataEventIn.Value)
+		observeResizeElements()
+		observeIntersectElements()
+		restorePanZoom()
+		return
+	}
+
+	if dataEventIn.Type == "append" {
+		currentElement.Call("insertAdjacentHTML", "beforeend", fmt.Sprint(dataEventIn.Value))
+		return
+	}
+
+	if dataEventIn.Type == "remove" {
+		currentElement.Call("remove")
+	}
+
+	if dataEventIn.Type == "addNode" {
+		var d = document.Call("createElement", "div")
+		currentElement.Set("innerHTML", fmt.Sprint(dataEventIn.Value))
+		currentElement.Call("appendChild", d)
+	}
+
+	if dataEventIn.Type == "text" {
+		if dataEventIn.Value != "" {
+			currentElement.Set("innerText", dataEventIn.Value)
+		}
+	}
+
+	if dataEventIn.Type == "style" {
+		currentElement.Get("style").Set("cssText", dataEventIn.Value)
+	}
+
+	if dataEventIn.Type == "set" {
+		currentElement.Set("value", dataEventIn.Value)
+	}
+
+	if dataEventIn.Type == "propertie" {
+		currentElement.Set(dataEventIn.Propertie, dataEventIn.Value)
+	}
+
+	if dataEventIn.Type == "scroll" {
+		currentElement.Call("scrollIntoView", map[string]interface{}{"behavior": dataEventIn.Value})
+	}
+
+	if dataEventIn.Type == "focus" {
+		currentElement.Call("focus")
+		if selectText, ok := dataEventIn.Value.(bool); ok && selectText && !currentElement.Get("select").IsUndefined() {
+			currentElement.Call("select")
+		}
+	}
+
+	if dataEventIn.Type == "get" {
+		dataEventOut := DataEventOut{}
+		dataEventOut.Type = "get"
+		dataEventOut.IdRet = dataEventIn.IdRet
+		if dataEventIn.SubType == "value" {
+			value := currentElement.Get("value")
+			dataEventOut.Data = GetValue(value)
+		}
+		if dataEventIn.SubType == "html" {
+			value := currentElement.Get("innerHTML")
+			dataEventOut.Data = GetValue(value)
+		}
+		if dataEventIn.SubType == "text" {
+			value := currentElement.Get("innerText")
+			dataEventOut.Data = GetValue(value)
+		}
+		if dataEventIn.SubType == "style" {
+			value := currentElement.Get("style").Get(fmt.Sprint(dataEventIn.Value))
+			dataEventOut.Data = GetValue(value)
+		}
+
+		if dataEventIn.SubType == "propertie" {
+			prop := currentElement.Get(fmt.Sprint(dataEventIn.Value))
+			dataEventOut.Data = GetValue(prop)
+		}
+		sendGet(dataEventOut.IdRet, dataEventOut.Data)
+	}
+}
+
+// showOfflineBanner add class liveview-disconnected to body and the banner of template liveview-offline-banner-template
+func showOfflineBanner() {
+	body := document.Get("body")
+	body.Get("classList").Call("add", "liveview-disconnected")
+	if !document.Call("getElementById", "liveview-offline-banner").IsNull() {
+		return
+	}
+	banner := document.Call("createElement", "div")
+	banner.Set("id", "liveview-offline-banner")
+	if template := document.Call("getElementById", "liveview-offline-banner-template"); !template.IsNull() {
+		banner.Set("innerHTML", template.Get("innerHTML"))
+	} else {
+		banner.Set("innerText", "Reconnecting…")
+	}
+	body.Call("appendChild", banner)
+}
+
+func hideOfflineBanner() {
+	document.Get("body").Get("classList").Call("remove", "liveview-disconnected")
+	if banner := document.Call("getElementById", "liveview-offline-banner"); !banner.IsNull() {
+		banner.Call("remove")
+	}
+}
+
+// registerServiceWorker register the service worker of meta liveview-service-worker
+func registerServiceWorker() {
+	meta := document.Call("querySelector", `meta[name="liveview-service-worker"]`)
+	serviceWorker := js.Global().Get("navigator").Get("serviceWorker")
+	if meta.IsNull() || serviceWorker.IsUndefined() {
+		return
+	}
+	serviceWorker.Call("register", meta.Call("getAttribute", "content").String()).Call("catch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		console.Call("log", "service worker registration failed", args[0])
+		return nil
+	}))
+}
+
 func GetValue(prop js.Value) interface{} {
 	switch prop.Type() {
 	case js.TypeBoolean:
@@ -215,6 +394,12 @@ func GetValue(prop js.Value) interface{} {
 	return nil
 }
 
+func sendGet(idRet string, data interface{}) {
+	dataEventOut := DataEventOut{Type: "get", IdRet: idRet, Data: data}
+	jsonBytes, _ := json.Marshal(&dataEventOut)
+	ws.Call("send", string(jsonBytes))
+}
+
 func sendEvent(id string, event string, data string) {
 	msgEvent := MsgEvent{
 		Type:  "data",
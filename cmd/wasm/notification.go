@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+type NotificationData struct {
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	Icon        string `json:"icon"`
+	ClickAction string `json:"click_action"`
+}
+
+func initNotification() {
+	js.Global().Set("RequestNotificationPermission", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		componentID := args[0].String()
+		notification := js.Global().Get("Notification")
+		if notification.IsUndefined() {
+			sendEvent(componentID, "NotificationPermission", "denied")
+			return nil
+		}
+		notification.Call("requestPermission").Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			sendEvent(componentID, "NotificationPermission", args[0].String())
+			return nil
+		}))
+		return nil
+	}))
+}
+
+func notificationPermission() interface{} {
+	notification := js.Global().Get("Notification")
+	if notification.IsUndefined() {
+		return ""
+	}
+	return notification.Get("permission").String()
+}
+
+// ShowNotification show the notification of message {"type":"notification"}
+func ShowNotification(componentID string, value interface{}) {
+	notification := js.Global().Get("Notification")
+	if notification.IsUndefined() {
+		return
+	}
+	var data NotificationData
+	jsonBytes, _ := json.Marshal(value)
+	json.Unmarshal(jsonBytes, &data)
+	n := notification.New(data.Title, map[string]interface{}{"body": data.Body, "icon": data.Icon})
+	if data.ClickAction != "" {
+		n.Set("onclick", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			window.Call("focus")
+			sendEvent(componentID, "NotificationClick", data.ClickAction)
+			return nil
+		}))
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"strings"
+	"syscall/js"
+	"unicode"
+)
+
+// otpInputs return the inputs of OTPInput component in order of data-otp-index
+func otpInputs(componentID string) []js.Value {
+	var inputs []js.Value
+	elements := document.Call("querySelectorAll", "input[data-otp-component]")
+	for i := 0; i < elements.Length(); i++ {
+		if elements.Index(i).Call("getAttribute", "data-otp-component").String() == componentID {
+			inputs = append(inputs, elements.Index(i))
+		}
+	}
+	return inputs
+}
+
+// otpFilter keep the valid chars of data-otp-type (numeric or alphanumeric)
+func otpFilter(value string, numeric bool) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) || (!numeric && unicode.IsLetter(r)) {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+// otpFill write the valid chars of text from the input target (the chars of a paste go to the next inputs), focus the
+// next input and send OTPChange, and OTPComplete when all inputs are filled (with data-otp-autosubmit it also submits
+// the form)
+func otpFill(target js.Value, text string) {
+	id := target.Call("getAttribute", "data-otp-component").String()
+	inputs := otpInputs(id)
+	index := 0
+	for i, input := range inputs {
+		if input.Equal(target) {
+			index = i
+		}
+	}
+	chars := []rune(otpFilter(text, target.Call("getAttribute", "data-otp-type").String() == "numeric"))
+	if len(chars) == 0 {
+		target.Set("value", "")
+	}
+	next := index
+	for i, r := range chars {
+		if index+i >= len(inputs) {
+			break
+		}
+		inputs[index+i].Set("value", string(r))
+		next = index + i + 1
+	}
+	if len(chars) > 0 && next < len(inputs) {
+		inputs[next].Call("focus")
+		inputs[next].Call("select")
+	}
+	code, complete := otpCode(inputs)
+	sendEvent(id, "OTPChange", code)
+	if complete {
+		sendEvent(id, "OTPComplete", code)
+		if target.Call("hasAttribute", "data-otp-autosubmit").Bool() {
+			if form := target.Get("form"); !form.IsNull() && !form.IsUndefined() {
+				form.Call("requestSubmit")
+			}
+		}
+	}
+}
+
+// otpCode return the chars of inputs and true if all inputs are filled
+func otpCode(inputs []js.Value) (string, bool) {
+	code := ""
+	complete := true
+	for _, input := range inputs {
+		value := input.Get("value").String()
+		if value == "" {
+			complete = false
+		}
+		code += value
+	}
+	return code, complete
+}
+
+// otpTarget return the input of event if it has data-otp-component
+func otpTarget(event js.Value) (js.Value, bool) {
+	target := event.Get("target")
+	if target.Get("getAttribute").IsUndefined() || target.Call("getAttribute", "data-otp-component").IsNull() {
+		return js.Null(), false
+	}
+	return target, true
+}
+
+// initOTP handle the inputs with data-otp-component: the focus moves to the next input on input and to the previous on
+// Backspace in an empty input, and a paste fills the next inputs (the inputs have maxlength 1)
+func initOTP() {
+	document.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if target, ok := otpTarget(args[0]); ok {
+			otpFill(target, target.Get("value").String())
+		}
+		return nil
+	}))
+	document.Call("addEventListener", "paste", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		target, ok := otpTarget(args[0])
+		if !ok || args[0].Get("clipboardData").IsUndefined() {
+			return nil
+		}
+		args[0].Call("preventDefault")
+		otpFill(target, args[0].Get("clipboardData").Call("getData", "text").String())
+		return nil
+	}))
+	document.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		target, ok := otpTarget(event)
+		if !ok {
+			return nil
+		}
+		id := target.Call("getAttribute", "data-otp-component").String()
+		inputs := otpInputs(id)
+		index := -1
+		for i, input := range inputs {
+			if input.Equal(target) {
+				index = i
+			}
+		}
+		key := event.Get("key").String()
+		move := 0
+		switch {
+		case key == "Backspace" && target.Get("value").String() == "" && index > 0:
+			inputs[index-1].Set("value", "")
+			code, _ := otpCode(inputs)
+			sendEvent(id, "OTPChange", code)
+			move = -1
+		case key == "ArrowLeft" && index > 0:
+			move = -1
+		case key == "ArrowRight" && index >= 0 && index < len(inputs)-1:
+			move = 1
+		}
+		if move != 0 {
+			event.Call("preventDefault")
+			inputs[index+move].Call("focus")
+			inputs[index+move].Call("select")
+		}
+		return nil
+	}))
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall/js"
+)
+
+var panZoomViewBox = make(map[string][4]float64)
+
+func getViewBox(svg js.Value) ([4]float64, bool) {
+	var box [4]float64
+	parts := strings.Fields(strings.ReplaceAll(svg.Call("getAttribute", "viewBox").String(), ",", " "))
+	if len(parts) != 4 {
+		return box, false
+	}
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return box, false
+		}
+		box[i] = v
+	}
+	return box, true
+}
+
+func setViewBox(svg js.Value, box [4]float64) {
+	svg.Call("setAttribute", "viewBox", fmt.Sprintf("%g %g %g %g", box[0], box[1], box[2], box[3]))
+	if id := svg.Get("id").String(); id != "" {
+		panZoomViewBox[id] = box
+	}
+}
+
+// initPanZoom pan (drag) and zoom (wheel) the viewBox of svg[data-panzoom]
+func initPanZoom() {
+	var (
+		dragging js.Value = js.Null()
+		lastX    float64
+		lastY    float64
+	)
+	closest := func(event js.Value) js.Value {
+		target := event.Get("target")
+		if target.Get("closest").IsUndefined() {
+			return js.Null()
+		}
+		return target.Call("closest", "svg[data-panzoom]")
+	}
+
+	document.Call("addEventListener", "wheel", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		event := args[0]
+		svg := closest(event)
+		if svg.IsNull() {
+			return nil
+		}
+		box, ok := getViewBox(svg)
+		if !ok {
+			return nil
+		}
+		event.Call("preventDefault")
+		rect := svg.Call("getBoundingClientRect")
+		px := (event.Get("clientX").Float() - rect.Get("left").Float()) / rect.Get("width").Float()
+		py := (event.Get("clientY").Float() - rect.Get("top").Float()) / rect.Get("height").Float()
+		scale := 1.1
+		if event.Get("deltaY").Float() < 0 {
+			scale = 1 / scale
+		}
+		width, height := box[2]*scale, box[3]*scale
+		box[0] += (box[2] - width) * px
+		box[1] += (box[3] - height) * py
+		box[2], box[3] = width, height
+		setViewBox(svg, box)
+		return nil
+	}), map[string]interface{}{"passive": false})
+
+	document.Call("addEventListener", "mousedown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		svg := closest(args[0])
+		if svg.IsNull() {
+			return nil
+		}
+		dragging = svg
+		lastX, lastY = args[0].Get("clientX").Float(), args[0].Get("clientY").Float()
+		return nil
+	}))
+
+	document.Call("addEventListener", "mousemove", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if dragging.IsNull() {
+			return nil
+		}
+		box, ok := getViewBox(dragging)
+		if !ok {
+			return nil
+		}
+		x, y := args[0].Get("clientX").Float(), args[0].Get("clientY").Float()
+		rect := dragging.Call("getBoundingClientRect")
+		box[0] -= (x - lastX) * box[2] / rect.Get("width").Float()
+		box[1] -= (y - lastY) * box[3] / rect.Get("height").Float()
+		lastX, lastY = x, y
+		setViewBox(dragging, box)
+		return nil
+	}))
+
+	document.Call("addEventListener", "mouseup", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		dragging = js.Null()
+		return nil
+	}))
+}
+
+// restorePanZoom keep the pan and zoom of svg[data-panzoom] after fill
+func restorePanZoom() {
+	elements := document.Call("querySelectorAll", "svg[data-panzoom]")
+	for i := 0; i < elements.Length(); i++ {
+		svg := elements.Index(i)
+		if box, ok := panZoomViewBox[svg.Get("id").String()]; ok {
+			setViewBox(svg, box)
+		}
+	}
+}
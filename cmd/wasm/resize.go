@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"syscall/js"
+)
+
+type ResizeData struct {
+	Width     float64 `json:"width"`
+	Height    float64 `json:"height"`
+	ElementID string  `json:"elementId"`
+}
+
+var (
+	resizeObserver js.Value
+	resizeTimers   = make(map[string]js.Value)
+)
+
+// initResizeObserver send Resize event to data-resize-component when the size of element changes (debounce data-resize-debounce ms, default 100)
+func initResizeObserver() {
+	if js.Global().Get("ResizeObserver").IsUndefined() {
+		return
+	}
+	resizeObserver = js.Global().Get("ResizeObserver").New(js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		entries := args[0]
+		for i := 0; i < entries.Length(); i++ {
+			entry := entries.Index(i)
+			target := entry.Get("target")
+			componentID := target.Call("getAttribute", "data-resize-component").String()
+			data := ResizeData{
+				Width:     entry.Get("contentRect").Get("width").Float(),
+				Height:    entry.Get("contentRect").Get("height").Float(),
+				ElementID: target.Get("id").String(),
+			}
+			debounce := 100
+			if value := target.Call("getAttribute", "data-resize-debounce"); !value.IsNull() {
+				if ms, err := strconv.Atoi(value.String()); err == nil {
+					debounce = ms
+				}
+			}
+			key := componentID + "|" + data.ElementID
+			if timer, ok := resizeTimers[key]; ok {
+				js.Global().Call("clearTimeout", timer)
+			}
+			var fx js.Func
+			fx = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+				defer fx.Release()
+				delete(resizeTimers, key)
+				jsonBytes, _ := json.Marshal(&data)
+				sendEvent(componentID, "Resize", string(jsonBytes))
+				return nil
+			})
+			resizeTimers[key] = js.Global().Call("setTimeout", fx, debounce)
+		}
+		return nil
+	}))
+	observeResizeElements()
+}
+
+// observeResizeElements observe the new elements with data-resize-component, it is called after each fill
+func observeResizeElements() {
+	if resizeObserver.IsUndefined() {
+		return
+	}
+	elements := document.Call("querySelectorAll", "[data-resize-component]:not([data-resize-observed])")
+	for i := 0; i < elements.Length(); i++ {
+		element := elements.Index(i)
+		element.Call("setAttribute", "data-resize-observed", "true")
+		resizeObserver.Call("observe", element)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"math/rand"
+	"syscall/js"
+	"time"
+)
+
+// reconnectAfter delays the reconnection after prepareShutdown, so the clients do not reconnect all at the same time
+var reconnectAfter time.Time
+
+// initShutdown define liveview.prepareShutdown(), the server sends it before shutdown for close the websocket and
+// reconnect (to other server) after a random delay of 1-5 seconds
+func initShutdown() {
+	liveview := js.Global().Get("liveview")
+	if liveview.IsUndefined() {
+		liveview = js.Global().Get("Object").New()
+		js.Global().Set("liveview", liveview)
+	}
+	liveview.Set("prepareShutdown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		reconnectAfter = time.Now().Add(time.Second + time.Duration(rand.Int63n(int64(4*time.Second))))
+		showOfflineBanner()
+		ws.Call("close")
+		return nil
+	}))
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"syscall/js"
+)
+
+var sliderTimers = make(map[string]js.Value)
+
+// sliderInputs return the range inputs of component, the inputs of DualSlider have data-slider-role low and high
+func sliderInputs(componentID string) []js.Value {
+	var inputs []js.Value
+	elements := document.Call("querySelectorAll", "input[data-slider-component]")
+	for i := 0; i < elements.Length(); i++ {
+		if elements.Index(i).Call("getAttribute", "data-slider-component").String() == componentID {
+			inputs = append(inputs, elements.Index(i))
+		}
+	}
+	return inputs
+}
+
+// initSlider update the data-slider-output of range inputs with data-slider-component while they are dragged and send
+// the event of data-slider-event (debounce 50ms), the value is the number or [low, high] when there are two inputs
+func initSlider() {
+	document.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		target := args[0].Get("target")
+		if target.Get("getAttribute").IsUndefined() {
+			return nil
+		}
+		componentID := target.Call("getAttribute", "data-slider-component")
+		if componentID.IsNull() {
+			return nil
+		}
+		id := componentID.String()
+		inputs := sliderInputs(id)
+		// the thumbs of DualSlider do not cross
+		if len(inputs) == 2 {
+			low, high := inputs[0].Get("value").String(), inputs[1].Get("value").String()
+			lowValue, _ := strconv.ParseFloat(low, 64)
+			highValue, _ := strconv.ParseFloat(high, 64)
+			if lowValue > highValue {
+				if target.Call("getAttribute", "data-slider-role").String() == "low" {
+					target.Set("value", high)
+				} else {
+					target.Set("value", low)
+				}
+			}
+		}
+		values := make([]string, len(inputs))
+		numbers := make([]float64, len(inputs))
+		for i, input := range inputs {
+			values[i] = input.Get("value").String()
+			numbers[i], _ = strconv.ParseFloat(values[i], 64)
+		}
+		outputs := document.Call("querySelectorAll", "[data-slider-output]")
+		for i := 0; i < outputs.Length(); i++ {
+			if outputs.Index(i).Call("getAttribute", "data-slider-output").String() == id {
+				outputs.Index(i).Set("textContent", strings.Join(values, " – "))
+			}
+		}
+		event := target.Call("getAttribute", "data-slider-event").String()
+		data := strings.Join(values, "")
+		if len(inputs) > 1 {
+			jsonBytes, _ := json.Marshal(numbers)
+			data = string(jsonBytes)
+		}
+		if timer, ok := sliderTimers[id]; ok {
+			js.Global().Call("clearTimeout", timer)
+		}
+		var fx js.Func
+		fx = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			defer fx.Release()
+			delete(sliderTimers, id)
+			sendEvent(id, event, data)
+			return nil
+		})
+		sliderTimers[id] = js.Global().Call("setTimeout", fx, 50)
+		return nil
+	}))
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"syscall/js"
+)
+
+// initSplitPanels drag the .divider of [data-split-component], the panels are the previous and next siblings of divider.
+// It send Resize with the ratio (max 60 per second) while dragging and ResizeEnd when the drag stops
+func initSplitPanels() {
+	var (
+		container js.Value = js.Null()
+		divider   js.Value
+		ratio     float64
+		lastSent  float64
+	)
+	minSize := func(name string) float64 {
+		value := container.Call("getAttribute", name)
+		if value.IsNull() {
+			return 0
+		}
+		v, _ := strconv.ParseFloat(value.String(), 64)
+		return v
+	}
+
+	document.Call("addEventListener", "mousedown", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		target := args[0].Get("target")
+		if target.Get("closest").IsUndefined() || !target.Get("classList").Call("contains", "divider").Bool() {
+			return nil
+		}
+		element := target.Call("closest", "[data-split-component]")
+		if element.IsNull() {
+			return nil
+		}
+		args[0].Call("preventDefault")
+		container, divider = element, target
+		return nil
+	}))
+
+	document.Call("addEventListener", "mousemove", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if container.IsNull() {
+			return nil
+		}
+		rect := container.Call("getBoundingClientRect")
+		var position, size float64
+		if container.Call("getAttribute", "data-split-direction").String() == "vertical" {
+			position = args[0].Get("clientY").Float() - rect.Get("top").Float()
+			size = rect.Get("height").Float()
+		} else {
+			position = args[0].Get("clientX").Float() - rect.Get("left").Float()
+			size = rect.Get("width").Float()
+		}
+		if size <= 0 {
+			return nil
+		}
+		if min := minSize("data-split-min-first"); position < min {
+			position = min
+		}
+		if min := minSize("data-split-min-second"); position > size-min {
+			position = size - min
+		}
+		ratio = position / size
+		if ratio < 0 {
+			ratio = 0
+		}
+		if ratio > 1 {
+			ratio = 1
+		}
+		divider.Get("previousElementSibling").Get("style").Set("flexBasis", fmt.Sprintf("%g%%", ratio*100))
+		divider.Get("nextElementSibling").Get("style").Set("flexBasis", fmt.Sprintf("%g%%", (1-ratio)*100))
+		now := js.Global().Get("Date").Call("now").Float()
+		if now-lastSent >= 1000/60 {
+			lastSent = now
+			sendEvent(container.Call("getAttribute", "data-split-component").String(), "Resize", strconv.FormatFloat(ratio, 'f', 4, 64))
+		}
+		return nil
+	}))
+
+	document.Call("addEventListener", "mouseup", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if container.IsNull() {
+			return nil
+		}
+		sendEvent(container.Call("getAttribute", "data-split-component").String(), "ResizeEnd", strconv.FormatFloat(ratio, 'f', 4, 64))
+		container = js.Null()
+		return nil
+	}))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strconv"
+	"syscall/js"
+	"time"
+)
+
+var videoLastProgress = make(map[string]time.Time)
+
+// initVideo send VideoProgress (max one each 500ms), VideoEnded and VideoState (playing true/false) of the
+// video elements with data-video-component, the media events do not bubble so they are captured in document
+func initVideo() {
+	handler := func(fx func(id string, video js.Value, event string)) js.Func {
+		return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			target := args[0].Get("target")
+			if target.Get("getAttribute").IsUndefined() {
+				return nil
+			}
+			componentID := target.Call("getAttribute", "data-video-component")
+			if componentID.IsNull() {
+				return nil
+			}
+			fx(componentID.String(), target, args[0].Get("type").String())
+			return nil
+		})
+	}
+	document.Call("addEventListener", "timeupdate", handler(func(id string, video js.Value, event string) {
+		if time.Since(videoLastProgress[id]) < 500*time.Millisecond {
+			return
+		}
+		videoLastProgress[id] = time.Now()
+		sendEvent(id, "VideoProgress", strconv.FormatFloat(video.Get("currentTime").Float(), 'f', 3, 64))
+	}), true)
+	document.Call("addEventListener", "ended", handler(func(id string, video js.Value, event string) {
+		delete(videoLastProgress, id)
+		sendEvent(id, "VideoEnded", strconv.FormatFloat(video.Get("currentTime").Float(), 'f', 3, 64))
+	}), true)
+	state := handler(func(id string, video js.Value, event string) {
+		sendEvent(id, "VideoState", strconv.FormatBool(event == "play"))
+	})
+	document.Call("addEventListener", "play", state, true)
+	document.Call("addEventListener", "pause", state, true)
+}
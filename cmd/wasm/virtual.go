@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+type VirtualScrollData struct {
+	ScrollTop    float64 `json:"scrollTop"`
+	ClientHeight float64 `json:"clientHeight"`
+}
+
+var virtualScrollPending = make(map[string]bool)
+
+// initVirtualScroll send VirtualScroll to data-virtual-component when the element is scrolled (max one per animation frame)
+func initVirtualScroll() {
+	document.Call("addEventListener", "scroll", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		target := args[0].Get("target")
+		if target.Get("getAttribute").IsUndefined() {
+			return nil
+		}
+		componentID := target.Call("getAttribute", "data-virtual-component")
+		if componentID.IsNull() {
+			return nil
+		}
+		id := componentID.String()
+		if virtualScrollPending[id] {
+			return nil
+		}
+		virtualScrollPending[id] = true
+		var fx js.Func
+		fx = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			defer fx.Release()
+			delete(virtualScrollPending, id)
+			data := VirtualScrollData{
+				ScrollTop:    target.Get("scrollTop").Float(),
+				ClientHeight: target.Get("clientHeight").Float(),
+			}
+			jsonBytes, _ := json.Marshal(&data)
+			sendEvent(id, "VirtualScroll", string(jsonBytes))
+			return nil
+		})
+		js.Global().Call("requestAnimationFrame", fx)
+		return nil
+	}), true)
+}
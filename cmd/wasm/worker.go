@@ -0,0 +1,123 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+)
+
+//go:embed worker.js
+var workerShim string
+
+var (
+	worker          js.Value
+	workerCallbacks = make(map[int]func(result js.Value))
+	workerNextID    int
+	// workerFuncs are the functions that RunInWorker can run, data is the JSON of argument
+	workerFuncs = map[string]func(data string) (interface{}, error){
+		"json_format": func(data string) (interface{}, error) {
+			var value interface{}
+			if err := json.Unmarshal([]byte(data), &value); err != nil {
+				return nil, err
+			}
+			// data is the JSON of a string with the JSON to format
+			if s, ok := value.(string); ok {
+				if err := json.Unmarshal([]byte(s), &value); err != nil {
+					return nil, err
+				}
+			}
+			b, err := json.MarshalIndent(value, "", "  ")
+			return string(b), err
+		},
+	}
+)
+
+// runWorkerFunc run fn of workerFuncs and return the result or {error}
+func runWorkerFunc(fn string, data string) interface{} {
+	f, ok := workerFuncs[fn]
+	if !ok {
+		return map[string]interface{}{"error": "unknown worker function " + fn}
+	}
+	result, err := f(data)
+	if err != nil {
+		return map[string]interface{}{"error": err.Error()}
+	}
+	return result
+}
+
+// runWorker is the main of json.wasm in the Web Worker, it defines liveviewWorkerRun for worker.js
+func runWorker() {
+	js.Global().Set("liveviewWorkerRun", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return runWorkerFunc(args[0].String(), args[1].String())
+	}))
+	<-make(chan struct{})
+}
+
+// initWorker define liveview.runInWorker(fn, data, callback) for the scripts of page
+func initWorker() {
+	liveview := js.Global().Get("liveview")
+	if liveview.IsUndefined() {
+		liveview = js.Global().Get("Object").New()
+		js.Global().Set("liveview", liveview)
+	}
+	liveview.Set("runInWorker", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		callback := args[2]
+		var data interface{} = GetValue(args[1])
+		if args[1].Type() == js.TypeObject {
+			data = json.RawMessage(js.Global().Get("JSON").Call("stringify", args[1]).String())
+		}
+		RunInWorker(args[0].String(), data, func(result js.Value) {
+			callback.Invoke(result)
+		})
+		return nil
+	}))
+}
+
+// startWorker create the Web Worker from worker.js, it is only created if the page has an element with data-use-worker
+func startWorker() bool {
+	if !worker.IsUndefined() {
+		return true
+	}
+	if js.Global().Get("Worker").IsUndefined() || document.Call("querySelector", "[data-use-worker]").IsNull() {
+		return false
+	}
+	blob := js.Global().Get("Blob").New([]interface{}{workerShim}, map[string]interface{}{"type": "text/javascript"})
+	worker = js.Global().Get("Worker").New(js.Global().Get("URL").Call("createObjectURL", blob))
+	worker.Set("onmessage", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		msg := args[0].Get("data")
+		id := msg.Get("id").Int()
+		callback, ok := workerCallbacks[id]
+		if !ok {
+			return nil
+		}
+		delete(workerCallbacks, id)
+		if !msg.Get("error").IsUndefined() {
+			fmt.Println("Worker error:", msg.Get("error").String())
+			callback(js.ValueOf(map[string]interface{}{"error": msg.Get("error").String()}))
+			return nil
+		}
+		callback(msg.Get("result"))
+		return nil
+	}))
+	base := js.Global().Get("URL").New(".", loc.Get("href")).Get("href").String()
+	worker.Call("postMessage", map[string]interface{}{"type": "init", "base": base})
+	return true
+}
+
+// RunInWorker run fn of workerFuncs with data in the Web Worker and call callback with the result,
+// without data-use-worker in the page (or without Worker support) it runs in the main thread
+func RunInWorker(fn string, data interface{}, callback func(result js.Value)) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		callback(js.ValueOf(map[string]interface{}{"error": err.Error()}))
+		return
+	}
+	if !startWorker() {
+		callback(js.ValueOf(runWorkerFunc(fn, string(b))))
+		return
+	}
+	workerNextID++
+	workerCallbacks[workerNextID] = callback
+	worker.Call("postMessage", map[string]interface{}{"id": workerNextID, "fn": fn, "data": string(b)})
+}
@@ -0,0 +1,20 @@
+// worker.js is embedded in json.wasm (worker.go) and it runs json.wasm in a Web Worker for RunInWorker.
+// The first message is {type: "init", base} with the url of page, the next ones are {id, fn, data}.
+let ready = null;
+
+self.onmessage = (event) => {
+	const msg = event.data;
+	if (msg.type === "init") {
+		importScripts(msg.base + "assets/wasm_exec.js");
+		const go = new Go();
+		ready = WebAssembly.instantiateStreaming(fetch(msg.base + "assets/json.wasm"), go.importObject).then((result) => {
+			go.run(result.instance);
+		});
+		return;
+	}
+	ready.then(() => {
+		self.postMessage({ id: msg.id, result: self.liveviewWorkerRun(msg.fn, msg.data) });
+	}).catch((error) => {
+		self.postMessage({ id: msg.id, error: String(error) });
+	});
+};
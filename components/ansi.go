@@ -0,0 +1,50 @@
+package components
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ansiRegexp = regexp.MustCompile("\x1b\\[([0-9;]*)m")
+	ansiColors = map[int]string{
+		30: "#000", 31: "#c62828", 32: "#2e7d32", 33: "#f9a825", 34: "#1565c0", 35: "#6a1b9a", 36: "#00838f", 37: "#ccc",
+		90: "#666", 91: "#ef5350", 92: "#66bb6a", 93: "#ffee58", 94: "#42a5f5", 95: "#ab47bc", 96: "#26c6da", 97: "#fff",
+	}
+)
+
+// ansiToHTML escape s and convert the ANSI codes of color and bold to span
+func ansiToHTML(s string) string {
+	sb := &strings.Builder{}
+	open := false
+	last := 0
+	for _, m := range ansiRegexp.FindAllStringSubmatchIndex(s, -1) {
+		sb.WriteString(html.EscapeString(s[last:m[0]]))
+		last = m[1]
+		if open {
+			sb.WriteString("</span>")
+			open = false
+		}
+		style := ""
+		for _, code := range strings.Split(s[m[2]:m[3]], ";") {
+			n, _ := strconv.Atoi(code)
+			if n == 1 {
+				style += "font-weight:bold;"
+			}
+			if color, ok := ansiColors[n]; ok {
+				style += "color:" + color + ";"
+			}
+		}
+		if style != "" {
+			sb.WriteString(`<span style="` + style + `">`)
+			open = true
+		}
+	}
+	sb.WriteString(html.EscapeString(s[last:]))
+	if open {
+		sb.WriteString("</span>")
+	}
+	return sb.String()
+}
@@ -0,0 +1,150 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type AutocompleteSuggestion struct {
+	Value     string
+	Label     string
+	Secondary string
+	Icon      string
+}
+
+type Autocomplete struct {
+	*liveview.ComponentDriver[*Autocomplete]
+	Name        string
+	Label       string
+	Placeholder string
+	Value       string
+	// MinChars is the min length of query for search (default 2)
+	MinChars int
+	// Debounce is the time without typing before send Search (default 300ms)
+	Debounce time.Duration
+	// FreeText allow values that they are not in suggestions
+	FreeText    bool
+	Suggestions []AutocompleteSuggestion
+	Error       string
+	OnSearch    func(query string) ([]AutocompleteSuggestion, error)
+	OnSelect    func(suggestion AutocompleteSuggestion)
+}
+
+func (t *Autocomplete) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Autocomplete) Start() {
+	if t.MinChars == 0 {
+		t.MinChars = 2
+	}
+	if t.Debounce == 0 {
+		t.Debounce = 300 * time.Millisecond
+	}
+	t.Commit()
+}
+
+func (t *Autocomplete) DebounceMs() int64 {
+	return t.Debounce.Milliseconds()
+}
+
+func (t *Autocomplete) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="autocomplete" style="position:relative">
+	{{if .Label}}<label for="{{.IdComponent}}_input">{{html .Label}}</label>{{end}}
+	<input type="text" id="{{.IdComponent}}_input" name="{{html .Name}}" value="{{html .Value}}" placeholder="{{html .Placeholder}}" autocomplete="off"
+		role="combobox" aria-autocomplete="list" aria-controls="{{.IdComponent}}_list" aria-expanded="{{.Expanded}}"
+		oninput="clearTimeout(this._t); var v = this.value; this._t = setTimeout(function(){ send_event('{{.IdComponent}}', 'Search', v) }, {{.DebounceMs}})"
+		onkeydown="var l = document.getElementById('{{.IdComponent}}_list'), items = l.querySelectorAll('li[role=option]'), i = parseInt(l.dataset.active || '-1');
+			if (event.key == 'ArrowDown' || event.key == 'ArrowUp') {
+				event.preventDefault();
+				i = event.key == 'ArrowDown' ? Math.min(i + 1, items.length - 1) : Math.max(i - 1, 0);
+				items.forEach(function(e, j){ e.setAttribute('aria-selected', j == i) });
+				l.dataset.active = i;
+				if (items[i]) this.setAttribute('aria-activedescendant', items[i].id);
+			} else if (event.key == 'Enter') {
+				event.preventDefault();
+				if (i >= 0 && items[i]) send_event('{{.IdComponent}}', 'Select', String(i)); else send_event('{{.IdComponent}}', 'SelectText', this.value);
+			} else if (event.key == 'Escape') {
+				l.innerHTML = ''; l.dataset.active = -1; this.setAttribute('aria-expanded', 'false'); this.removeAttribute('aria-activedescendant');
+			}"/>
+	<ul id="{{.IdComponent}}_list" role="listbox" style="position:absolute;list-style:none;margin:0;padding:0;background:#fff;z-index:100">{{.RenderSuggestions}}</ul>
+</div>`
+}
+
+// Expanded report if the list has suggestions or the error
+func (t *Autocomplete) Expanded() bool {
+	return len(t.Suggestions) > 0 || t.Error != ""
+}
+
+// RenderSuggestions return the li of suggestions and the li of Error (it is in the list so the refill of Search shows it)
+func (t *Autocomplete) RenderSuggestions() string {
+	sb := &strings.Builder{}
+	if t.Error != "" {
+		fmt.Fprintf(sb, `<li class="autocomplete-error" role="alert" style="color:red;padding:4px">%s</li>`, html.EscapeString(t.Error))
+	}
+	for i, s := range t.Suggestions {
+		fmt.Fprintf(sb, `<li id="%s_option_%d" role="option" aria-selected="false" style="cursor:pointer;padding:4px" onclick="send_event('%s','Select','%d')">`, t.IdComponent, i, t.IdComponent, i)
+		if s.Icon != "" {
+			sb.WriteString(s.Icon + " ")
+		}
+		label := s.Label
+		if label == "" {
+			label = s.Value
+		}
+		sb.WriteString(html.EscapeString(label))
+		if s.Secondary != "" {
+			fmt.Fprintf(sb, `<br/><small style="color:gray">%s</small>`, html.EscapeString(s.Secondary))
+		}
+		sb.WriteString(`</li>`)
+	}
+	return sb.String()
+}
+
+func (t *Autocomplete) Search(data interface{}) {
+	query := fmt.Sprint(data)
+	t.Suggestions = nil
+	t.Error = ""
+	if len([]rune(query)) >= t.MinChars && t.OnSearch != nil {
+		suggestions, err := t.OnSearch(query)
+		if err != nil {
+			t.Error = err.Error()
+		}
+		t.Suggestions = suggestions
+	}
+	// only the list is filled for keep the focus of input, the active item and the attributes of input are reset
+	t.FillValueById(t.IdComponent+"_list", t.RenderSuggestions())
+	t.EvalScript(fmt.Sprintf(`var l = document.getElementById(%s), i = document.getElementById(%s);
+if (l) { l.dataset.active = -1; }
+if (i) { i.setAttribute('aria-expanded', '%t'); i.removeAttribute('aria-activedescendant'); }`,
+		jsJSON(t.IdComponent+"_list"), jsJSON(t.IdComponent+"_input"), t.Expanded()))
+}
+
+func (t *Autocomplete) Select(data interface{}) {
+	i, err := strconv.Atoi(fmt.Sprint(data))
+	if err != nil || i < 0 || i >= len(t.Suggestions) {
+		return
+	}
+	t.selectSuggestion(t.Suggestions[i])
+}
+
+func (t *Autocomplete) SelectText(data interface{}) {
+	if !t.FreeText {
+		return
+	}
+	text := fmt.Sprint(data)
+	t.selectSuggestion(AutocompleteSuggestion{Value: text, Label: text})
+}
+
+func (t *Autocomplete) selectSuggestion(suggestion AutocompleteSuggestion) {
+	t.Value = suggestion.Value
+	t.Suggestions = nil
+	if t.OnSelect != nil {
+		t.OnSelect(suggestion)
+	}
+	t.Commit()
+}
@@ -1,11 +1,24 @@
 package components
 
-import "github.com/arturoeanton/go-echo-live-view/liveview"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
 
 type Button struct {
 	*liveview.ComponentDriver[*Button]
 	I       int
 	Caption string
+	// Loading render the button disabled with LoadingLabel (or spinner if it is empty)
+	Loading      bool
+	LoadingLabel string
+	// PreventDoubleClick set Loading on click and reset it after CooldownDuration (default 1s)
+	PreventDoubleClick bool
+	CooldownDuration   time.Duration
+	mu                 sync.Mutex
 }
 
 func (t *Button) Start() {
@@ -13,14 +26,54 @@ func (t *Button) Start() {
 }
 
 func (t *Button) GetTemplate() string {
-	return `<Button id="{{.IdComponent}}" onclick="send_event(this.id,'Click')" >{{.Caption}}</button>`
+	return `<Button id="{{.IdComponent}}" {{if .Loading}}disabled class="btn-loading"{{end}} onclick="send_event(this.id,'Click')" >{{if .Loading}}{{if .LoadingLabel}}{{.LoadingLabel}}{{else}}<svg width="14" height="14" viewBox="0 0 50 50"><circle cx="25" cy="25" r="20" fill="none" stroke="currentColor" stroke-width="5" stroke-dasharray="90 150"><animateTransform attributeName="transform" type="rotate" from="0 25 25" to="360 25 25" dur="1s" repeatCount="indefinite"/></circle></svg>{{end}}{{else}}{{.Caption}}{{end}}</button>`
 }
 
 func (t *Button) GetDriver() liveview.LiveDriver {
 	return t
 }
 
-func (t *Button) SetClick(fx func(c *Button, data interface{})) *Button {
-	t.Events["Click"] = fx
+// SetLoading set Loading and Commit
+func (t *Button) SetLoading(loading bool) {
+	t.mu.Lock()
+	t.Loading = loading
+	t.mu.Unlock()
+	t.Commit()
+}
+
+// startLoading set Loading and return true if it was not set, the check and set are atomic so only one click runs
+func (t *Button) startLoading() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Loading {
+		return false
+	}
+	t.Loading = true
+	return true
+}
+
+func (t *Button) SetClick(fx func(c *Button, ctx context.Context, data interface{})) *Button {
+	t.Events["Click"] = func(c *Button, ctx context.Context, data interface{}) {
+		if c.PreventDoubleClick {
+			if !c.startLoading() {
+				return
+			}
+			c.Commit()
+			cooldown := c.CooldownDuration
+			if cooldown == 0 {
+				cooldown = time.Second
+			}
+			start := time.Now()
+			// the button is enabled after the handler returns and at least cooldown after the click
+			defer func() {
+				if wait := cooldown - time.Since(start); wait > 0 {
+					time.AfterFunc(wait, func() { c.SetLoading(false) })
+					return
+				}
+				c.SetLoading(false)
+			}()
+		}
+		fx(c, ctx, data)
+	}
 	return t
 }
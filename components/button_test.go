@@ -0,0 +1,62 @@
+package components
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+// startComponent start the driver of c with a channel that is drained
+func startComponent[T liveview.Component](id string, c T) {
+	driver := liveview.NewDriver(id, c)
+	channel := make(chan map[string]interface{})
+	go func() {
+		for range channel {
+		}
+	}()
+	drivers := make(map[string]liveview.LiveDriver)
+	channelIn := make(map[string]chan interface{})
+	driver.StartDriver(&drivers, &channelIn, channel)
+}
+
+func (t *Button) loading() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.Loading
+}
+
+func TestButtonPreventDoubleClick(t *testing.T) {
+	b := &Button{PreventDoubleClick: true, CooldownDuration: time.Millisecond}
+	startComponent("button", b)
+	var calls int32
+	release := make(chan struct{})
+	b.SetClick(func(c *Button, ctx context.Context, data interface{}) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.Events["Click"](b, context.Background(), nil)
+		}()
+	}
+	// the cooldown is over but the handler is still running
+	time.Sleep(20 * time.Millisecond)
+	if !b.loading() {
+		t.Error("Loading is false while the handler runs")
+	}
+	close(release)
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("the handler ran %d times, want 1", calls)
+	}
+	if b.loading() {
+		t.Error("Loading is true after the handler and the cooldown")
+	}
+}
@@ -0,0 +1,285 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type PaletteCommand struct {
+	ID          string
+	Label       string
+	Description string
+	Icon        string
+	Shortcut    string
+	Group       string
+	Action      func()
+	SubCommands []PaletteCommand
+}
+
+type CommandPalette struct {
+	*liveview.ComponentDriver[*CommandPalette]
+	Commands []PaletteCommand
+	// OnSearch rewrite the fuzzy filter of Commands
+	OnSearch func(query string) []PaletteCommand
+	// Hotkey open the palette (default "Meta+k")
+	Hotkey      string
+	Placeholder string
+	Open        bool
+	Query       string
+	// Path are the ids of commands opened with SubCommands
+	Path []string
+	// Recent are the ids of last executed commands, they are shown first when the query is empty
+	Recent    []string
+	MaxRecent int
+	visible   []PaletteCommand
+}
+
+func (t *CommandPalette) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *CommandPalette) Start() {
+	if t.Hotkey == "" {
+		t.Hotkey = "Meta+k"
+	}
+	if t.Placeholder == "" {
+		t.Placeholder = "Type a command..."
+	}
+	if t.MaxRecent == 0 {
+		t.MaxRecent = 5
+	}
+	t.Commit()
+}
+
+func (t *CommandPalette) GetTemplate() string {
+	return `<div id="{{.IdComponent}}">
+	<span hidden data-hotkey="{{.Hotkey}}" data-hotkey-component="{{.IdComponent}}" data-hotkey-event="OpenPalette" data-hotkey-global></span>
+	{{if .Open}}
+	<div style="position:fixed;inset:0;background:rgba(0,0,0,.4);z-index:1000" onclick="if (event.target == this) send_event('{{.IdComponent}}', 'ClosePalette')">
+		<div role="dialog" aria-modal="true" style="max-width:560px;margin:10vh auto;background:#fff;border-radius:8px;padding:8px">
+			<input type="text" id="{{.IdComponent}}_input" value="{{html .Query}}" placeholder="{{html .Placeholder}}" autocomplete="off" style="width:100%"
+				role="combobox" aria-autocomplete="list" aria-controls="{{.IdComponent}}_list" aria-expanded="true"
+				oninput="clearTimeout(this._t); var v = this.value; this._t = setTimeout(function(){ send_event('{{.IdComponent}}', 'Search', v) }, 100)"
+				onkeydown="var l = document.getElementById('{{.IdComponent}}_list'), items = l.querySelectorAll('li[data-id]'), i = parseInt(l.dataset.active || '0');
+					if (event.key == 'ArrowDown' || event.key == 'ArrowUp') {
+						event.preventDefault();
+						i = event.key == 'ArrowDown' ? Math.min(i + 1, items.length - 1) : Math.max(i - 1, 0);
+						items.forEach(function(e, j){ e.setAttribute('aria-selected', j == i); e.style.background = j == i ? '#eee' : '' });
+						l.dataset.active = i;
+					} else if (event.key == 'Enter') {
+						event.preventDefault();
+						if (items[i]) send_event('{{.IdComponent}}', 'ExecuteCommand', items[i].dataset.id);
+					} else if (event.key == 'Escape') {
+						send_event('{{.IdComponent}}', 'ClosePalette');
+					} else if (event.key == 'Backspace' && this.value == '') {
+						send_event('{{.IdComponent}}', 'Back');
+					}"/>
+			<ul id="{{.IdComponent}}_list" role="listbox" style="list-style:none;margin:0;padding:0;max-height:50vh;overflow:auto">{{.RenderCommands}}</ul>
+		</div>
+	</div>
+	{{end}}
+</div>`
+}
+
+// current return the commands of the last opened command in Path
+func (t *CommandPalette) current() []PaletteCommand {
+	commands := t.Commands
+	for _, id := range t.Path {
+		found := false
+		for _, c := range commands {
+			if c.ID == id {
+				commands, found = c.SubCommands, true
+				break
+			}
+		}
+		if !found {
+			return commands
+		}
+	}
+	return commands
+}
+
+func findPaletteCommand(commands []PaletteCommand, id string) *PaletteCommand {
+	for i := range commands {
+		if commands[i].ID == id {
+			return &commands[i]
+		}
+		if c := findPaletteCommand(commands[i].SubCommands, id); c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// Filter return the commands that match with query, sorted by score of fuzzy match
+func (t *CommandPalette) Filter(query string) []PaletteCommand {
+	if t.OnSearch != nil && query != "" {
+		return t.OnSearch(query)
+	}
+	commands := t.current()
+	if query == "" {
+		if len(t.Path) > 0 {
+			return commands
+		}
+		result := make([]PaletteCommand, 0, len(commands))
+		for _, id := range t.Recent {
+			if c := findPaletteCommand(commands, id); c != nil {
+				recent := *c
+				recent.Group = "Recent"
+				result = append(result, recent)
+			}
+		}
+		for _, c := range commands {
+			if !liveview.ContainsString(t.Recent, c.ID) {
+				result = append(result, c)
+			}
+		}
+		return result
+	}
+	type scored struct {
+		command PaletteCommand
+		score   int
+	}
+	matches := make([]scored, 0)
+	for _, c := range commands {
+		if score, ok := fuzzyScore(query, c.Label); ok {
+			matches = append(matches, scored{c, score})
+		}
+	}
+	for i := 1; i < len(matches); i++ {
+		for j := i; j > 0 && matches[j].score > matches[j-1].score; j-- {
+			matches[j], matches[j-1] = matches[j-1], matches[j]
+		}
+	}
+	result := make([]PaletteCommand, len(matches))
+	for i, m := range matches {
+		result[i] = m.command
+	}
+	return result
+}
+
+// fuzzyScore return true if all runes of query are in text in order, the score is better with consecutive runes and start of words
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	s := []rune(strings.ToLower(text))
+	score, qi, last := 0, 0, -2
+	for i := 0; i < len(s) && qi < len(q); i++ {
+		if s[i] != q[qi] {
+			continue
+		}
+		score++
+		if last == i-1 {
+			score += 2
+		}
+		if i == 0 || s[i-1] == ' ' {
+			score += 3
+		}
+		last = i
+		qi++
+	}
+	return score, qi == len(q)
+}
+
+// RenderCommands return the li of commands with headers of groups
+func (t *CommandPalette) RenderCommands() string {
+	t.visible = t.Filter(t.Query)
+	sb := &strings.Builder{}
+	group := ""
+	for i, c := range t.visible {
+		if c.Group != group {
+			group = c.Group
+			fmt.Fprintf(sb, `<li role="presentation" style="color:gray;font-size:small;padding:4px">%s</li>`, html.EscapeString(group))
+		}
+		background := ""
+		if i == 0 {
+			background = "background:#eee"
+		}
+		fmt.Fprintf(sb, `<li role="option" data-id="%s" aria-selected="%t" style="cursor:pointer;padding:6px;%s" onclick="send_event('%s','ExecuteCommand',this.dataset.id)">`, html.EscapeString(c.ID), i == 0, background, t.IdComponent)
+		if c.Icon != "" {
+			sb.WriteString(c.Icon + " ")
+		}
+		sb.WriteString(html.EscapeString(c.Label))
+		if len(c.SubCommands) > 0 {
+			sb.WriteString(" &rsaquo;")
+		}
+		if c.Shortcut != "" {
+			fmt.Fprintf(sb, `<kbd style="float:right">%s</kbd>`, html.EscapeString(c.Shortcut))
+		}
+		if c.Description != "" {
+			fmt.Fprintf(sb, `<br/><small style="color:gray">%s</small>`, html.EscapeString(c.Description))
+		}
+		sb.WriteString(`</li>`)
+	}
+	return sb.String()
+}
+
+func (t *CommandPalette) OpenPalette(data interface{}) {
+	t.Open = true
+	t.Query = ""
+	t.Path = nil
+	t.Commit()
+	t.FocusElement(t.IdComponent+"_input", false)
+}
+
+func (t *CommandPalette) ClosePalette(data interface{}) {
+	t.Open = false
+	t.Commit()
+}
+
+func (t *CommandPalette) Search(data interface{}) {
+	t.Query = fmt.Sprint(data)
+	t.FillValueById(t.IdComponent+"_list", t.RenderCommands())
+}
+
+func (t *CommandPalette) Back(data interface{}) {
+	if len(t.Path) == 0 {
+		return
+	}
+	t.Path = t.Path[:len(t.Path)-1]
+	t.Query = ""
+	t.Commit()
+	t.FocusElement(t.IdComponent+"_input", false)
+}
+
+func (t *CommandPalette) ExecuteCommand(data interface{}) {
+	id := fmt.Sprint(data)
+	var command *PaletteCommand
+	for i := range t.visible {
+		if t.visible[i].ID == id {
+			command = &t.visible[i]
+			break
+		}
+	}
+	if command == nil {
+		command = findPaletteCommand(t.Commands, id)
+	}
+	if command == nil {
+		return
+	}
+	if len(command.SubCommands) > 0 {
+		t.Path = append(t.Path, command.ID)
+		t.Query = ""
+		t.Commit()
+		t.FocusElement(t.IdComponent+"_input", false)
+		return
+	}
+	t.addRecent(command.ID)
+	t.Open = false
+	t.Commit()
+	if command.Action != nil {
+		command.Action()
+	}
+}
+
+func (t *CommandPalette) addRecent(id string) {
+	recent := []string{id}
+	for _, r := range t.Recent {
+		if r != id && len(recent) < t.MaxRecent {
+			recent = append(recent, r)
+		}
+	}
+	t.Recent = recent
+}
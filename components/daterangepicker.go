@@ -0,0 +1,204 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type DateRangePreset struct {
+	Label string
+	Range func(now time.Time) (start, end time.Time)
+}
+
+type DateRangePicker struct {
+	*liveview.ComponentDriver[*DateRangePicker]
+	StartDate time.Time
+	EndDate   time.Time
+	MinDate   *time.Time
+	MaxDate   *time.Time
+	// ViewMonth is the first month shown, the second is the next month
+	ViewMonth time.Time
+	Presets   []DateRangePreset
+	OnChange  func(start, end time.Time)
+}
+
+// DefaultDateRangePresets return presets "Today", "Last 7 days", "Last 30 days", "This month" and "Last month"
+func DefaultDateRangePresets() []DateRangePreset {
+	return []DateRangePreset{
+		{Label: "Today", Range: func(now time.Time) (time.Time, time.Time) { return now, now }},
+		{Label: "Last 7 days", Range: func(now time.Time) (time.Time, time.Time) { return now.AddDate(0, 0, -6), now }},
+		{Label: "Last 30 days", Range: func(now time.Time) (time.Time, time.Time) { return now.AddDate(0, 0, -29), now }},
+		{Label: "This month", Range: func(now time.Time) (time.Time, time.Time) {
+			first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+			return first, first.AddDate(0, 1, -1)
+		}},
+		{Label: "Last month", Range: func(now time.Time) (time.Time, time.Time) {
+			first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+			return first, first.AddDate(0, 1, -1)
+		}},
+	}
+}
+
+func (t *DateRangePicker) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *DateRangePicker) Start() {
+	if t.ViewMonth.IsZero() {
+		t.ViewMonth = t.StartDate
+		if t.ViewMonth.IsZero() {
+			t.ViewMonth = time.Now()
+		}
+	}
+	t.ViewMonth = firstOfMonth(t.ViewMonth)
+	t.Commit()
+}
+
+func (t *DateRangePicker) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="date-range-picker" tabindex="0"
+	onkeydown="if (event.key == 'ArrowLeft') send_event(this.id, 'PrevMonth'); if (event.key == 'ArrowRight') send_event(this.id, 'NextMonth');">
+	<div>
+		{{range $i, $p := .Presets}}<button onclick="send_event('{{$.IdComponent}}', 'Preset', '{{$i}}')">{{html $p.Label}}</button> {{end}}
+	</div>
+	<div style="display:flex;gap:16px;align-items:flex-start">
+		<button onclick="send_event('{{.IdComponent}}', 'PrevMonth')" aria-label="Previous month">&lsaquo;</button>
+		{{.RenderMonth 0}}
+		{{.RenderMonth 1}}
+		<button onclick="send_event('{{.IdComponent}}', 'NextMonth')" aria-label="Next month">&rsaquo;</button>
+	</div>
+	<div>{{.RangeLabel}}</div>
+</div>`
+}
+
+// RangeLabel return "2006-01-02 - 2006-01-02"
+func (t *DateRangePicker) RangeLabel() string {
+	label := ""
+	if !t.StartDate.IsZero() {
+		label = t.StartDate.Format("2006-01-02")
+	}
+	if !t.EndDate.IsZero() {
+		label += " - " + t.EndDate.Format("2006-01-02")
+	}
+	return label
+}
+
+// RenderMonth return the table of month ViewMonth + offset
+func (t *DateRangePicker) RenderMonth(offset int) string {
+	month := t.ViewMonth.AddDate(0, offset, 0)
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<table class="month"><caption>%s %d</caption><tr>`, month.Month(), month.Year())
+	for _, d := range []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"} {
+		fmt.Fprintf(sb, `<th>%s</th>`, d)
+	}
+	sb.WriteString(`</tr><tr>`)
+	for i := 0; i < int(month.Weekday()); i++ {
+		sb.WriteString(`<td></td>`)
+	}
+	for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Sunday && day.Day() != 1 {
+			sb.WriteString(`</tr><tr>`)
+		}
+		key := dateKey(day)
+		style := "cursor:pointer;text-align:center;padding:4px"
+		switch {
+		case !t.StartDate.IsZero() && (key == dateKey(t.StartDate) || (!t.EndDate.IsZero() && key == dateKey(t.EndDate))):
+			style += ";background:#1976d2;color:#fff"
+		case !t.StartDate.IsZero() && !t.EndDate.IsZero() && key > dateKey(t.StartDate) && key < dateKey(t.EndDate):
+			style += ";background:#bbdefb"
+		}
+		if !t.allowed(day) {
+			fmt.Fprintf(sb, `<td style="text-align:center;padding:4px;color:#ccc">%d</td>`, day.Day())
+			continue
+		}
+		fmt.Fprintf(sb, `<td style="%s" onclick="send_event('%s','SelectDate','%s')">%d</td>`, style, t.IdComponent, day.Format("2006-01-02"), day.Day())
+	}
+	sb.WriteString(`</tr></table>`)
+	return sb.String()
+}
+
+func (t *DateRangePicker) allowed(day time.Time) bool {
+	if t.MinDate != nil && dateKey(day) < dateKey(*t.MinDate) {
+		return false
+	}
+	if t.MaxDate != nil && dateKey(day) > dateKey(*t.MaxDate) {
+		return false
+	}
+	return true
+}
+
+func (t *DateRangePicker) location() *time.Location {
+	if !t.ViewMonth.IsZero() {
+		return t.ViewMonth.Location()
+	}
+	return time.Local
+}
+
+func (t *DateRangePicker) SelectDate(data interface{}) {
+	day, err := time.ParseInLocation("2006-01-02", fmt.Sprint(data), t.location())
+	if err != nil || !t.allowed(day) {
+		return
+	}
+	if t.StartDate.IsZero() || !t.EndDate.IsZero() {
+		t.StartDate, t.EndDate = day, time.Time{}
+		t.Commit()
+		return
+	}
+	if dateKey(day) < dateKey(t.StartDate) {
+		t.StartDate, t.EndDate = day, t.StartDate
+	} else {
+		t.EndDate = day
+	}
+	t.changed()
+}
+
+// SetRange set start and end (swap if end is before start), call OnChange and Commit
+func (t *DateRangePicker) SetRange(start, end time.Time) {
+	if dateKey(end) < dateKey(start) {
+		start, end = end, start
+	}
+	t.StartDate, t.EndDate = truncateDay(start), truncateDay(end)
+	t.ViewMonth = firstOfMonth(t.StartDate)
+	t.changed()
+}
+
+func (t *DateRangePicker) changed() {
+	if t.OnChange != nil {
+		t.OnChange(t.StartDate, t.EndDate)
+	}
+	t.Commit()
+}
+
+func (t *DateRangePicker) Preset(data interface{}) {
+	i, err := strconv.Atoi(fmt.Sprint(data))
+	if err != nil || i < 0 || i >= len(t.Presets) {
+		return
+	}
+	t.SetRange(t.Presets[i].Range(time.Now().In(t.location())))
+}
+
+func (t *DateRangePicker) PrevMonth(data interface{}) {
+	t.ViewMonth = t.ViewMonth.AddDate(0, -1, 0)
+	t.Commit()
+}
+
+func (t *DateRangePicker) NextMonth(data interface{}) {
+	t.ViewMonth = t.ViewMonth.AddDate(0, 1, 0)
+	t.Commit()
+}
+
+// dateKey return yyyymmdd for compare dates without hours (it is safe with daylight saving time)
+func dateKey(d time.Time) int {
+	return d.Year()*10000 + int(d.Month())*100 + d.Day()
+}
+
+func truncateDay(d time.Time) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, d.Location())
+}
+
+func firstOfMonth(d time.Time) time.Time {
+	return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, d.Location())
+}
@@ -0,0 +1,298 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type DiffStats struct {
+	Added   int
+	Removed int
+}
+
+// diffLine is a line of diff, Kind is "=", "+" or "-"
+type diffLine struct {
+	Kind    string
+	Text    string
+	OldLine int
+	NewLine int
+}
+
+type DiffViewer struct {
+	*liveview.ComponentDriver[*DiffViewer]
+	OldContent string
+	NewContent string
+	// Mode is "unified" (default) or "split"
+	Mode     string
+	OldTitle string
+	NewTitle string
+	// ContextLines are the unchanged lines shown around changes (default 3)
+	ContextLines int
+}
+
+func (t *DiffViewer) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *DiffViewer) Start() {
+	if t.Mode == "" {
+		t.Mode = "unified"
+	}
+	if t.ContextLines == 0 {
+		t.ContextLines = 3
+	}
+	t.Commit()
+}
+
+func (t *DiffViewer) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="diff-viewer" style="font-family:monospace;font-size:13px">
+	<div>
+		{{with .Stats}}<span style="color:#2e7d32">+{{.Added}}</span> <span style="color:#c62828">-{{.Removed}}</span>{{end}}
+		<button onclick="send_event('{{.IdComponent}}', 'SetMode', '{{if eq .Mode "split"}}unified{{else}}split{{end}}')">{{if eq .Mode "split"}}Unified{{else}}Split{{end}}</button>
+	</div>
+	{{.RenderDiff}}
+</div>`
+}
+
+// diffLines return the diff of lines with the longest common subsequence (Myers algorithm)
+func diffLines(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	total := n + m
+	v := make([]int, 2*total+2)
+	// trace keep v before each step d for the backtrack
+	trace := make([][]int, 0)
+	offset := total + 1
+	found := false
+	for d := 0; d <= total && !found; d++ {
+		trace = append(trace, append([]int{}, v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+	// backtrack from the end
+	result := make([]diffLine, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0 && (x > 0 || y > 0); d-- {
+		prev := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev[offset+k-1] < prev[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			result = append(result, diffLine{Kind: "=", Text: a[x], OldLine: x + 1, NewLine: y + 1})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			result = append(result, diffLine{Kind: "+", Text: b[y], NewLine: y + 1})
+		} else {
+			x--
+			result = append(result, diffLine{Kind: "-", Text: a[x], OldLine: x + 1})
+		}
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+func splitContentLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(s, "\r\n", "\n"), "\n"), "\n")
+}
+
+func (t *DiffViewer) diff() []diffLine {
+	return diffLines(splitContentLines(t.OldContent), splitContentLines(t.NewContent))
+}
+
+// Stats return the count of added and removed lines
+func (t *DiffViewer) Stats() DiffStats {
+	stats := DiffStats{}
+	for _, line := range t.diff() {
+		switch line.Kind {
+		case "+":
+			stats.Added++
+		case "-":
+			stats.Removed++
+		}
+	}
+	return stats
+}
+
+// visible return for each line if it is shown (changes and ContextLines around them)
+func (t *DiffViewer) visible(lines []diffLine) []bool {
+	visible := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.Kind == "=" {
+			continue
+		}
+		for j := i - t.ContextLines; j <= i+t.ContextLines; j++ {
+			if j >= 0 && j < len(lines) {
+				visible[j] = true
+			}
+		}
+	}
+	return visible
+}
+
+var diffStyles = map[string]string{"=": "color:#666", "+": "background:#e6ffed;color:#22863a", "-": "background:#ffeef0;color:#b31d28"}
+
+func diffNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
+
+// RenderDiff return the html of diff in Mode
+func (t *DiffViewer) RenderDiff() string {
+	lines := t.diff()
+	visible := t.visible(lines)
+	sb := &strings.Builder{}
+	if t.Mode == "split" {
+		return t.renderSplit(lines, visible)
+	}
+	sb.WriteString(`<div style="border:1px solid #ddd">`)
+	if t.OldTitle != "" || t.NewTitle != "" {
+		fmt.Fprintf(sb, `<div style="background:#f6f8fa;padding:4px">--- %s<br/>+++ %s</div>`, html.EscapeString(t.OldTitle), html.EscapeString(t.NewTitle))
+	}
+	sb.WriteString(`<table style="border-collapse:collapse;width:100%">`)
+	skipped := false
+	for i, line := range lines {
+		if !visible[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			sb.WriteString(`<tr><td colspan="3" style="background:#f1f8ff;color:#666">&hellip;</td></tr>`)
+			skipped = false
+		}
+		fmt.Fprintf(sb, `<tr style="%s"><td style="width:3em;text-align:right;color:#999">%s</td><td style="width:3em;text-align:right;color:#999">%s</td><td style="white-space:pre">%s %s</td></tr>`,
+			diffStyles[line.Kind], diffNumber(line.OldLine), diffNumber(line.NewLine), strings.Replace(line.Kind, "=", " ", 1), html.EscapeString(line.Text))
+	}
+	if skipped {
+		sb.WriteString(`<tr><td colspan="3" style="background:#f1f8ff;color:#666">&hellip;</td></tr>`)
+	}
+	sb.WriteString(`</table></div>`)
+	return sb.String()
+}
+
+// renderSplit return two columns with synchronized scroll, the removed and added lines are aligned in rows
+func (t *DiffViewer) renderSplit(lines []diffLine, visible []bool) string {
+	type row struct{ left, right *diffLine }
+	rows := make([]row, 0)
+	rowVisible := make([]bool, 0)
+	for i := 0; i < len(lines); i++ {
+		if lines[i].Kind == "=" {
+			rows = append(rows, row{&lines[i], &lines[i]})
+			rowVisible = append(rowVisible, visible[i])
+			continue
+		}
+		// a block of removed lines followed by added lines is shown side by side
+		removed, added := make([]*diffLine, 0), make([]*diffLine, 0)
+		for ; i < len(lines) && lines[i].Kind == "-"; i++ {
+			removed = append(removed, &lines[i])
+		}
+		for ; i < len(lines) && lines[i].Kind == "+"; i++ {
+			added = append(added, &lines[i])
+		}
+		i--
+		for j := 0; j < len(removed) || j < len(added); j++ {
+			r := row{}
+			if j < len(removed) {
+				r.left = removed[j]
+			}
+			if j < len(added) {
+				r.right = added[j]
+			}
+			rows = append(rows, r)
+			rowVisible = append(rowVisible, true)
+		}
+	}
+	side := func(sb *strings.Builder, title string, right bool) {
+		name, other := "old", "new"
+		if right {
+			name, other = other, name
+		}
+		fmt.Fprintf(sb, `<div id="%s_%s" style="flex:1;overflow:auto;max-height:600px;border:1px solid #ddd" onscroll="var o = document.getElementById('%s_%s'); if (o && o.scrollTop != this.scrollTop) o.scrollTop = this.scrollTop">`,
+			t.IdComponent, name, t.IdComponent, other)
+		// both sides have title for keep the rows aligned
+		if t.OldTitle != "" || t.NewTitle != "" {
+			fmt.Fprintf(sb, `<div style="background:#f6f8fa;padding:4px">%s&nbsp;</div>`, html.EscapeString(title))
+		}
+		sb.WriteString(`<table style="border-collapse:collapse;width:100%">`)
+		skipped := false
+		for i, r := range rows {
+			if !rowVisible[i] {
+				skipped = true
+				continue
+			}
+			if skipped {
+				sb.WriteString(`<tr><td colspan="2" style="background:#f1f8ff;color:#666">&hellip;</td></tr>`)
+				skipped = false
+			}
+			line := r.left
+			if right {
+				line = r.right
+			}
+			if line == nil {
+				sb.WriteString(`<tr style="background:#fafbfc"><td style="width:3em">&nbsp;</td><td></td></tr>`)
+				continue
+			}
+			number := line.OldLine
+			if right {
+				number = line.NewLine
+			}
+			fmt.Fprintf(sb, `<tr style="%s"><td style="width:3em;text-align:right;color:#999">%s</td><td style="white-space:pre">%s</td></tr>`,
+				diffStyles[line.Kind], diffNumber(number), html.EscapeString(line.Text))
+		}
+		sb.WriteString(`</table></div>`)
+	}
+	sb := &strings.Builder{}
+	sb.WriteString(`<div style="display:flex;gap:4px">`)
+	side(sb, t.OldTitle, false)
+	side(sb, t.NewTitle, true)
+	sb.WriteString(`</div>`)
+	return sb.String()
+}
+
+// SetContents change the old and new content and Commit
+func (t *DiffViewer) SetContents(old, new string) {
+	t.OldContent, t.NewContent = old, new
+	t.Commit()
+}
+
+func (t *DiffViewer) SetMode(data interface{}) {
+	t.Mode = fmt.Sprint(data)
+	t.Commit()
+}
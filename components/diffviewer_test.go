@@ -0,0 +1,160 @@
+package components
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// diffString return the kinds and texts of lines, as "=a +b -c"
+func diffString(lines []diffLine) string {
+	parts := make([]string, len(lines))
+	for i, line := range lines {
+		parts[i] = line.Kind + line.Text
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{"empty", "", "", ""},
+		{"identical", "a\nb\nc", "a\nb\nc", "=a =b =c"},
+		{"pure insert", "", "a\nb", "+a +b"},
+		{"pure delete", "a\nb", "", "-a -b"},
+		{"insert in middle", "a\nc", "a\nb\nc", "=a +b =c"},
+		{"delete in middle", "a\nb\nc", "a\nc", "=a -b =c"},
+		{"replace", "a\nb\nc", "a\nx\nc", "=a -b +x =c"},
+		{"crlf and trailing newline", "a\r\nb\r\n", "a\nb", "=a =b"},
+	}
+	for _, tt := range tests {
+		got := diffLines(splitContentLines(tt.old), splitContentLines(tt.new))
+		if s := diffString(got); s != tt.want {
+			t.Errorf("%s: diff = %q, want %q", tt.name, s, tt.want)
+		}
+	}
+}
+
+// TestDiffLinesRebuild check that the diff rebuild both contents with their line numbers and has the minimum edits
+func TestDiffLinesRebuild(t *testing.T) {
+	tests := []struct {
+		old, new string
+		edits    int
+	}{
+		{"a\nb\nc\na\nb\nb\na", "c\nb\na\nb\na\nc", 5},
+		{"x\ny\nz", "1\n2\n3", 6},
+		{"a\nb\nc\nd\ne", "a\nc\nd\ne\nf", 2},
+	}
+	for _, tt := range tests {
+		a, b := splitContentLines(tt.old), splitContentLines(tt.new)
+		lines := diffLines(a, b)
+		var old, new []string
+		edits := 0
+		for _, line := range lines {
+			if line.Kind != "+" {
+				old = append(old, line.Text)
+				if line.OldLine != len(old) {
+					t.Errorf("%q: old line %d, want %d", line.Text, line.OldLine, len(old))
+				}
+			}
+			if line.Kind != "-" {
+				new = append(new, line.Text)
+				if line.NewLine != len(new) {
+					t.Errorf("%q: new line %d, want %d", line.Text, line.NewLine, len(new))
+				}
+			}
+			if line.Kind != "=" {
+				edits++
+			}
+		}
+		if !reflect.DeepEqual(old, a) || !reflect.DeepEqual(new, b) {
+			t.Errorf("diff %q does not rebuild %q and %q", diffString(lines), tt.old, tt.new)
+		}
+		if edits != tt.edits {
+			t.Errorf("diff %q has %d edits, want %d", diffString(lines), edits, tt.edits)
+		}
+	}
+}
+
+func TestDiffViewerHunks(t *testing.T) {
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = string(rune('a' + i))
+	}
+	old := strings.Join(lines, "\n")
+	tests := []struct {
+		name    string
+		changed []int
+		context int
+		visible string
+		hunks   int
+	}{
+		// one change shows ContextLines on each side
+		{"single", []int{10}, 2, "........xx=xx.......", 1},
+		// the context of two near changes are merged in one hunk
+		{"merged", []int{5, 9}, 2, "...xx=xxx=xx........", 1},
+		{"adjacent context", []int{5, 10}, 2, "...xx=xxxx=xx.......", 1},
+		{"one line between contexts", []int{5, 11}, 2, "...xx=xx.xx=xx......", 2},
+		{"separated", []int{3, 12}, 2, ".xx=xx....xx=xx.....", 2},
+		{"at the edges", []int{0, 19}, 1, "=x................x=", 2},
+	}
+	for _, tt := range tests {
+		changed := append([]string{}, lines...)
+		for _, i := range tt.changed {
+			changed[i] = strings.ToUpper(changed[i])
+		}
+		dv := &DiffViewer{OldContent: old, NewContent: strings.Join(changed, "\n"), ContextLines: tt.context, Mode: "unified"}
+		diff := dv.diff()
+		visible := dv.visible(diff)
+		// a replaced line is "-" and "+", it is shown as one "=" in want
+		sb := &strings.Builder{}
+		for i, line := range diff {
+			switch {
+			case line.Kind == "+":
+			case line.Kind == "-":
+				sb.WriteByte('=')
+			case visible[i]:
+				sb.WriteByte('x')
+			default:
+				sb.WriteByte('.')
+			}
+		}
+		if sb.String() != tt.visible {
+			t.Errorf("%s: visible = %s, want %s", tt.name, sb.String(), tt.visible)
+		}
+		hidden := 0
+		for i := range diff {
+			if !visible[i] && (i == 0 || visible[i-1]) {
+				hidden++
+			}
+		}
+		if got := strings.Count(dv.RenderDiff(), "&hellip;"); got != hidden {
+			t.Errorf("%s: %d separators, want %d", tt.name, got, hidden)
+		}
+		if hunks := len(strings.Fields(strings.ReplaceAll(sb.String(), ".", " "))); hunks != tt.hunks {
+			t.Errorf("%s: %d hunks, want %d", tt.name, hunks, tt.hunks)
+		}
+	}
+}
+
+func TestDiffViewerStats(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     DiffStats
+	}{
+		{"", "", DiffStats{}},
+		{"a\nb", "a\nb", DiffStats{}},
+		{"", "a\nb\nc", DiffStats{Added: 3}},
+		{"a\nb", "", DiffStats{Removed: 2}},
+		{"a\nb\nc\nd", "a\nx\nc\ny\nz", DiffStats{Added: 3, Removed: 2}},
+	}
+	for _, tt := range tests {
+		dv := &DiffViewer{OldContent: tt.old, NewContent: tt.new}
+		if got := dv.Stats(); got != tt.want {
+			t.Errorf("Stats(%q, %q) = %+v, want %+v", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type GanttTask struct {
+	ID    string
+	Name  string
+	Start time.Time
+	End   time.Time
+	// Progress is between 0 and 1
+	Progress     float64
+	Dependencies []string
+	Color        string
+	Assignee     string
+	Category     string
+}
+
+type Gantt struct {
+	*liveview.ComponentDriver[*Gantt]
+	Tasks     []GanttTask
+	ViewStart time.Time
+	ViewEnd   time.Time
+	// GroupBy is "" (without groups), "assignee" or "category"
+	GroupBy string
+	// ShowCriticalPath mark the bars of CriticalPath
+	ShowCriticalPath bool
+	// Width of the chart panel in px (default 800)
+	Width        int
+	OnTaskMove   func(id string, newStart, newEnd time.Time) error
+	OnTaskResize func(id string, newEnd time.Time) error
+	Error        string
+	// Collapsed are the groups collapsed
+	Collapsed map[string]bool
+}
+
+const (
+	ganttRowHeight  = 28
+	ganttLabelWidth = 200
+	ganttHeader     = 30
+)
+
+type ganttRow struct {
+	group string
+	task  *GanttTask
+}
+
+func (t *Gantt) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Gantt) Start() {
+	if t.Width == 0 {
+		t.Width = 800
+	}
+	if t.Collapsed == nil {
+		t.Collapsed = make(map[string]bool)
+	}
+	if t.ViewStart.IsZero() || t.ViewEnd.IsZero() {
+		for i, task := range t.Tasks {
+			if i == 0 || task.Start.Before(t.ViewStart) {
+				t.ViewStart = truncateDay(task.Start)
+			}
+			if i == 0 || task.End.After(t.ViewEnd) {
+				t.ViewEnd = truncateDay(task.End).AddDate(0, 0, 1)
+			}
+		}
+		if len(t.Tasks) == 0 {
+			t.ViewStart = truncateDay(time.Now())
+			t.ViewEnd = t.ViewStart.AddDate(0, 0, 30)
+		}
+	}
+	t.Commit()
+}
+
+func (t *Gantt) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="gantt" style="overflow:auto">
+	{{if .Error}}<div class="gantt-error" style="color:red">{{html .Error}}</div>{{end}}
+	{{.RenderChart}}
+</div>`
+}
+
+// pxPerDay return the scale of chart
+func (t *Gantt) pxPerDay() float64 {
+	days := t.ViewEnd.Sub(t.ViewStart).Hours() / 24
+	if days <= 0 {
+		days = 1
+	}
+	return float64(t.Width) / days
+}
+
+func (t *Gantt) xOf(d time.Time) float64 {
+	return ganttLabelWidth + d.Sub(t.ViewStart).Hours()/24*t.pxPerDay()
+}
+
+func (t *Gantt) groupOf(task *GanttTask) string {
+	switch strings.ToLower(t.GroupBy) {
+	case "assignee":
+		return task.Assignee
+	case "category":
+		return task.Category
+	}
+	return ""
+}
+
+// rows return the rows of chart, with a row for each group if GroupBy is set
+func (t *Gantt) rows() []ganttRow {
+	rows := make([]ganttRow, 0, len(t.Tasks))
+	if t.GroupBy == "" {
+		for i := range t.Tasks {
+			rows = append(rows, ganttRow{task: &t.Tasks[i]})
+		}
+		return rows
+	}
+	groups := make([]string, 0)
+	byGroup := make(map[string][]*GanttTask)
+	for i := range t.Tasks {
+		group := t.groupOf(&t.Tasks[i])
+		if _, ok := byGroup[group]; !ok {
+			groups = append(groups, group)
+		}
+		byGroup[group] = append(byGroup[group], &t.Tasks[i])
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		rows = append(rows, ganttRow{group: group})
+		if t.Collapsed[group] {
+			continue
+		}
+		for _, task := range byGroup[group] {
+			rows = append(rows, ganttRow{group: group, task: task})
+		}
+	}
+	return rows
+}
+
+// RenderChart return the svg with names of tasks (left) and bars (right)
+func (t *Gantt) RenderChart() string {
+	rows := t.rows()
+	width := ganttLabelWidth + t.Width
+	height := ganttHeader + len(rows)*ganttRowHeight
+	critical := make(map[string]bool)
+	if t.ShowCriticalPath {
+		for _, id := range t.CriticalPath() {
+			critical[id] = true
+		}
+	}
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<svg data-gantt-component="%s" width="%d" height="%d" style="font-family:sans-serif;font-size:12px;user-select:none">`, t.IdComponent, width, height)
+	fmt.Fprintf(sb, `<defs><marker id="%s_arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto"><path d="M 0 0 L 10 5 L 0 10 z" fill="#555"/></marker></defs>`, t.IdComponent)
+
+	// header with days (or weeks if the days are too small)
+	step := 1
+	if t.pxPerDay() < 20 {
+		step = 7
+	}
+	for d := t.ViewStart; d.Before(t.ViewEnd); d = d.AddDate(0, 0, step) {
+		x := t.xOf(d)
+		fmt.Fprintf(sb, `<line x1="%.1f" y1="0" x2="%.1f" y2="%d" stroke="#eee"/><text x="%.1f" y="18" fill="#666">%s</text>`, x, x, height, x+2, d.Format("Jan 2"))
+	}
+	if now := time.Now(); now.After(t.ViewStart) && now.Before(t.ViewEnd) {
+		x := t.xOf(now)
+		fmt.Fprintf(sb, `<line x1="%.1f" y1="0" x2="%.1f" y2="%d" stroke="#f44336" stroke-dasharray="4"/>`, x, x, height)
+	}
+
+	position := make(map[string]int)
+	for i, row := range rows {
+		y := ganttHeader + i*ganttRowHeight
+		if row.task == nil {
+			arrow := "&#9662;"
+			if t.Collapsed[row.group] {
+				arrow = "&#9656;"
+			}
+			label := row.group
+			if label == "" {
+				label = "(none)"
+			}
+			fmt.Fprintf(sb, `<rect x="0" y="%d" width="%d" height="%d" fill="#f5f5f5"/>`, y, width, ganttRowHeight)
+			fmt.Fprintf(sb, `<text x="4" y="%d" font-weight="bold" style="cursor:pointer" onclick="send_event('%s','ToggleGroup','%s')">%s %s</text>`,
+				y+18, t.IdComponent, html.EscapeString(template.JSEscapeString(row.group)), arrow, html.EscapeString(label))
+			continue
+		}
+		task := row.task
+		position[task.ID] = i
+		indent := 4
+		if t.GroupBy != "" {
+			indent = 16
+		}
+		fmt.Fprintf(sb, `<text x="%d" y="%d">%s</text>`, indent, y+18, html.EscapeString(task.Name))
+		color := task.Color
+		if color == "" {
+			color = "#1976d2"
+		}
+		x1, x2 := t.xOf(task.Start), t.xOf(task.End)
+		barWidth := math.Max(x2-x1, 2)
+		stroke := ""
+		if critical[task.ID] {
+			stroke = ` stroke="#d32f2f" stroke-width="2"`
+		}
+		title := task.Name
+		if task.Assignee != "" {
+			title += " (" + task.Assignee + ")"
+		}
+		fmt.Fprintf(sb, `<g data-gantt-bar="%s" style="cursor:move"><title>%s</title>`, html.EscapeString(task.ID), html.EscapeString(title))
+		fmt.Fprintf(sb, `<rect x="%.1f" y="%d" width="%.1f" height="%d" rx="3" fill="%s" fill-opacity="0.4"%s/>`, x1, y+5, barWidth, ganttRowHeight-10, html.EscapeString(color), stroke)
+		fmt.Fprintf(sb, `<rect x="%.1f" y="%d" width="%.1f" height="%d" rx="3" fill="%s" pointer-events="none"/>`, x1, y+5, barWidth*math.Max(0, math.Min(1, task.Progress)), ganttRowHeight-10, html.EscapeString(color))
+		fmt.Fprintf(sb, `<rect class="gantt-handle" x="%.1f" y="%d" width="6" height="%d" fill="transparent" style="cursor:ew-resize"/>`, x1+barWidth-6, y+5, ganttRowHeight-10)
+		sb.WriteString(`</g>`)
+	}
+
+	// dependency arrows from end of dependency to start of task
+	for _, task := range t.Tasks {
+		i, ok := position[task.ID]
+		if !ok {
+			continue
+		}
+		for _, dep := range task.Dependencies {
+			j, ok := position[dep]
+			if !ok {
+				continue
+			}
+			d := t.FindTask(dep)
+			fx, fy := t.xOf(d.End), float64(ganttHeader+j*ganttRowHeight+ganttRowHeight/2)
+			tx, ty := t.xOf(task.Start), float64(ganttHeader+i*ganttRowHeight+ganttRowHeight/2)
+			fmt.Fprintf(sb, `<path d="M %.1f %.1f H %.1f V %.1f H %.1f" fill="none" stroke="#555" marker-end="url(#%s_arrow)"/>`, fx, fy, fx+8, ty, tx, t.IdComponent)
+		}
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// FindTask return the task with id or nil
+func (t *Gantt) FindTask(id string) *GanttTask {
+	for i := range t.Tasks {
+		if t.Tasks[i].ID == id {
+			return &t.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// CriticalPath return the ids of the longest chain of dependencies (by duration of tasks), the first is the start of chain
+func (t *Gantt) CriticalPath() []string {
+	finish := make(map[string]time.Duration)
+	previous := make(map[string]string)
+	visiting := make(map[string]bool)
+	var earliestFinish func(task *GanttTask) time.Duration
+	earliestFinish = func(task *GanttTask) time.Duration {
+		if f, ok := finish[task.ID]; ok {
+			return f
+		}
+		if visiting[task.ID] {
+			// cycle of dependencies
+			return 0
+		}
+		visiting[task.ID] = true
+		start := time.Duration(0)
+		for _, id := range task.Dependencies {
+			if dep := t.FindTask(id); dep != nil {
+				if f := earliestFinish(dep); f > start {
+					start, previous[task.ID] = f, id
+				}
+			}
+		}
+		visiting[task.ID] = false
+		finish[task.ID] = start + task.End.Sub(task.Start)
+		return finish[task.ID]
+	}
+	last := ""
+	for i := range t.Tasks {
+		if f := earliestFinish(&t.Tasks[i]); last == "" || f > finish[last] {
+			last = t.Tasks[i].ID
+		}
+	}
+	path := make([]string, 0)
+	for id := last; id != ""; id = previous[id] {
+		path = append([]string{id}, path...)
+	}
+	return path
+}
+
+// snapDays convert a delta in px to days
+func (t *Gantt) snapDays(dx float64) int {
+	return int(math.Round(dx / t.pxPerDay()))
+}
+
+func parseGanttDrag(data interface{}) (string, float64, bool) {
+	var drag struct {
+		ID string  `json:"id"`
+		DX float64 `json:"dx"`
+	}
+	if err := json.Unmarshal([]byte(fmt.Sprint(data)), &drag); err != nil {
+		return "", 0, false
+	}
+	return drag.ID, drag.DX, true
+}
+
+func (t *Gantt) TaskMove(data interface{}) {
+	id, dx, ok := parseGanttDrag(data)
+	task := t.FindTask(id)
+	if !ok || task == nil {
+		return
+	}
+	t.Error = ""
+	if days := t.snapDays(dx); days != 0 {
+		start, end := task.Start.AddDate(0, 0, days), task.End.AddDate(0, 0, days)
+		var err error
+		if t.OnTaskMove != nil {
+			err = t.OnTaskMove(id, start, end)
+		}
+		if err != nil {
+			t.Error = err.Error()
+		} else {
+			task.Start, task.End = start, end
+		}
+	}
+	// Commit also return the bar to its place if the move is rejected
+	t.Commit()
+}
+
+func (t *Gantt) TaskResize(data interface{}) {
+	id, dx, ok := parseGanttDrag(data)
+	task := t.FindTask(id)
+	if !ok || task == nil {
+		return
+	}
+	t.Error = ""
+	if days := t.snapDays(dx); days != 0 {
+		end := task.End.AddDate(0, 0, days)
+		if !end.After(task.Start) {
+			end = task.Start.AddDate(0, 0, 1)
+		}
+		var err error
+		if t.OnTaskResize != nil {
+			err = t.OnTaskResize(id, end)
+		}
+		if err != nil {
+			t.Error = err.Error()
+		} else {
+			task.End = end
+		}
+	}
+	t.Commit()
+}
+
+func (t *Gantt) ToggleGroup(data interface{}) {
+	group := fmt.Sprint(data)
+	if t.Collapsed == nil {
+		t.Collapsed = make(map[string]bool)
+	}
+	t.Collapsed[group] = !t.Collapsed[group]
+	t.Commit()
+}
@@ -0,0 +1,150 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type GaugeThreshold struct {
+	// Value is the end of band, the band starts at the previous threshold (or Min)
+	Value float64
+	Color string
+}
+
+type Gauge struct {
+	*liveview.ComponentDriver[*Gauge]
+	Value      float64
+	Min        float64
+	Max        float64
+	Label      string
+	Unit       string
+	Color      string
+	Thresholds []GaugeThreshold
+	// Target show a marker needle if it is not nil
+	Target *float64
+	// Animate transition the needle when Value changes
+	Animate bool
+	Size    int
+	// prevValue is the start of the animation of needle
+	prevValue float64
+}
+
+const (
+	gaugeCX = 100.0
+	gaugeCY = 100.0
+	gaugeR  = 80.0
+)
+
+func (t *Gauge) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Gauge) Start() {
+	if t.Max == t.Min {
+		t.Max = t.Min + 100
+	}
+	if t.Color == "" {
+		t.Color = "#1976d2"
+	}
+	if t.Size == 0 {
+		t.Size = 200
+	}
+	t.prevValue = t.Min
+	t.Commit()
+}
+
+func (t *Gauge) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="gauge">
+	<svg width="{{.Size}}" viewBox="0 0 200 130" role="meter" aria-valuemin="{{.Min}}" aria-valuemax="{{.Max}}" aria-valuenow="{{.Value}}" aria-label="{{html .Label}}">
+		{{.RenderArcs}}
+		{{if .Animate}}<style>@keyframes {{.IdComponent}}_needle { from { transform:rotate({{.PrevNeedleAngle}}deg) } to { transform:rotate({{.NeedleAngle}}deg) } }</style>{{end}}
+		<g style="transform-origin:100px 100px;transform:rotate({{.NeedleAngle}}deg){{if .Animate}};animation:{{.IdComponent}}_needle .6s ease-out{{end}}">
+			<line x1="100" y1="100" x2="30" y2="100" stroke="#333" stroke-width="3" stroke-linecap="round"/>
+		</g>
+		{{.RenderTarget}}
+		<circle cx="100" cy="100" r="6" fill="#333"/>
+		<text x="100" y="122" text-anchor="middle" font-size="16" font-weight="bold">{{.ValueLabel}}</text>
+	</svg>
+	{{if .Label}}<div style="text-align:center">{{html .Label}}</div>{{end}}
+</div>`
+}
+
+// ratio return the position of v between Min and Max in [0, 1]
+func (t *Gauge) ratio(v float64) float64 {
+	r := (v - t.Min) / (t.Max - t.Min)
+	return math.Max(0, math.Min(1, r))
+}
+
+// gaugePoint return the point of the arc for ratio r, 0 is left and 1 is right
+func gaugePoint(r float64) (float64, float64) {
+	angle := math.Pi * (1 - r)
+	return gaugeCX + gaugeR*math.Cos(angle), gaugeCY - gaugeR*math.Sin(angle)
+}
+
+func gaugeArc(from, to float64, color string, width int) string {
+	x1, y1 := gaugePoint(from)
+	x2, y2 := gaugePoint(to)
+	return fmt.Sprintf(`<path d="M %.2f %.2f A %.0f %.0f 0 0 1 %.2f %.2f" fill="none" stroke="%s" stroke-width="%d"/>`,
+		x1, y1, gaugeR, gaugeR, x2, y2, html.EscapeString(color), width)
+}
+
+// RenderArcs return the background (or the bands of thresholds) and the filled arc from Min to Value
+func (t *Gauge) RenderArcs() string {
+	sb := &strings.Builder{}
+	if len(t.Thresholds) == 0 {
+		sb.WriteString(gaugeArc(0, 1, "#eee", 16))
+	} else {
+		from := 0.0
+		for _, th := range t.Thresholds {
+			to := t.ratio(th.Value)
+			sb.WriteString(gaugeArc(from, to, th.Color, 16))
+			from = to
+		}
+		if from < 1 {
+			sb.WriteString(gaugeArc(from, 1, "#eee", 16))
+		}
+	}
+	if r := t.ratio(t.Value); r > 0 {
+		width := 16
+		if len(t.Thresholds) > 0 {
+			// the filled arc is thinner for show the bands
+			width = 6
+		}
+		sb.WriteString(gaugeArc(0, r, t.Color, width))
+	}
+	return sb.String()
+}
+
+// NeedleAngle return the rotation of needle (it points to left at 0 degrees)
+func (t *Gauge) NeedleAngle() string {
+	return fmt.Sprintf("%.2f", t.ratio(t.Value)*180)
+}
+
+func (t *Gauge) PrevNeedleAngle() string {
+	return fmt.Sprintf("%.2f", t.ratio(t.prevValue)*180)
+}
+
+func (t *Gauge) RenderTarget() string {
+	if t.Target == nil {
+		return ""
+	}
+	angle := math.Pi * (1 - t.ratio(*t.Target))
+	x1, y1 := gaugeCX+(gaugeR-12)*math.Cos(angle), gaugeCY-(gaugeR-12)*math.Sin(angle)
+	x2, y2 := gaugeCX+(gaugeR+12)*math.Cos(angle), gaugeCY-(gaugeR+12)*math.Sin(angle)
+	return fmt.Sprintf(`<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="#d32f2f" stroke-width="3"><title>target %g</title></line>`, x1, y1, x2, y2, *t.Target)
+}
+
+func (t *Gauge) ValueLabel() string {
+	return html.EscapeString(fmt.Sprintf("%g%s", t.Value, t.Unit))
+}
+
+// SetGaugeValue clip v to [Min, Max] and Commit (SetValue is the method of driver for set the value of element)
+func (t *Gauge) SetGaugeValue(v float64) {
+	t.prevValue = t.Value
+	t.Value = math.Max(t.Min, math.Min(t.Max, v))
+	t.Commit()
+}
@@ -0,0 +1,95 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type InfiniteScroll struct {
+	*liveview.ComponentDriver[*InfiniteScroll]
+	Items   []interface{}
+	HasMore bool
+	Loading bool
+	// Page is the last page loaded
+	Page       int
+	OnLoadMore func(page int) ([]interface{}, bool, error)
+	RenderItem func(item interface{}) string
+	EndMessage string
+	Error      string
+}
+
+func (t *InfiniteScroll) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *InfiniteScroll) Start() {
+	if t.EndMessage == "" {
+		t.EndMessage = "End of list"
+	}
+	t.Commit()
+}
+
+func (t *InfiniteScroll) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="infinite-scroll">
+	{{.RenderItems}}
+	{{if .Loading}}
+	<div class="infinite-scroll-loading" style="text-align:center;padding:8px">
+		<span style="display:inline-block;width:16px;height:16px;border:2px solid #ccc;border-top-color:#333;border-radius:50%;animation:liveview-spin 1s linear infinite"></span>
+		<style>@keyframes liveview-spin { to { transform: rotate(360deg) } }</style>
+	</div>
+	{{else if .Error}}
+	<div class="infinite-scroll-error" style="color:red">{{html .Error}} <button onclick="send_event('{{.IdComponent}}', 'LoadMore')">Retry</button></div>
+	{{else if .HasMore}}
+	<div data-intersect-component="{{.IdComponent}}" data-intersect-event="LoadMore" style="height:1px"></div>
+	{{else}}
+	<div class="infinite-scroll-end" style="text-align:center;color:gray;padding:8px">{{html .EndMessage}}</div>
+	{{end}}
+</div>`
+}
+
+// RenderItems return the html of items with RenderItem
+func (t *InfiniteScroll) RenderItems() string {
+	sb := &strings.Builder{}
+	for _, item := range t.Items {
+		if t.RenderItem != nil {
+			sb.WriteString(t.RenderItem(item))
+		} else {
+			sb.WriteString(`<div>` + html.EscapeString(fmt.Sprint(item)) + `</div>`)
+		}
+	}
+	return sb.String()
+}
+
+// LoadMore is sent by the sentinel when it is visible
+func (t *InfiniteScroll) LoadMore(data interface{}) {
+	if t.Loading || !t.HasMore || t.OnLoadMore == nil {
+		return
+	}
+	t.Loading = true
+	t.Error = ""
+	t.Commit()
+	items, hasMore, err := t.OnLoadMore(t.Page + 1)
+	t.Loading = false
+	if err != nil {
+		// the sentinel is replaced by a retry button
+		t.Error = err.Error()
+		t.Commit()
+		return
+	}
+	t.Items = append(t.Items, items...)
+	t.HasMore = hasMore
+	t.Page++
+	t.Commit()
+}
+
+// Reset remove the items and load again from the first page
+func (t *InfiniteScroll) Reset() {
+	t.Items = nil
+	t.Page = 0
+	t.HasMore = true
+	t.Error = ""
+	t.Commit()
+}
@@ -1,6 +1,10 @@
 package components
 
-import "github.com/arturoeanton/go-echo-live-view/liveview"
+import (
+	"context"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
 
 type InputText struct {
 	*liveview.ComponentDriver[*InputText]
@@ -30,7 +34,7 @@ func (t *InputText) KeyUp(data interface{}) {}
 
 func (t *InputText) Change(data interface{}) {}
 
-func (t *InputText) SetKeyUp(fx func(c *InputText, data interface{})) *InputText {
+func (t *InputText) SetKeyUp(fx func(c *InputText, ctx context.Context, data interface{})) *InputText {
 	t.Events["KeyUp"] = fx
 	return t
 }
@@ -0,0 +1,193 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type InspectorEntry struct {
+	Time time.Time
+	Name string
+	Data string
+}
+
+// Inspector show the state, the events and the commits of other component of the same connection, it uses the topics
+// liveview.debug.* of bus
+type Inspector struct {
+	*liveview.ComponentDriver[*Inspector]
+	TargetComponentID string
+	ShowState         bool
+	ShowEventLog      bool
+	ShowRenderStats   bool
+	// Container is the id of element where the inspector add its mount point (it is used by PageControl.Debug)
+	Container string
+	Collapsed bool
+	// MaxEvents is the size of event log (default 50)
+	MaxEvents int
+	// mu guards the log and Collapsed, the bus delivers in other goroutine than the events of inspector
+	mu          sync.Mutex
+	events      []InspectorEntry
+	commitCount int
+	lastRender  time.Duration
+	cancels     []func()
+}
+
+func init() {
+	liveview.NewInspector = func(targetID string, container string) liveview.Component {
+		inspector := &Inspector{TargetComponentID: targetID, Container: container, ShowState: true, ShowEventLog: true, ShowRenderStats: true, Collapsed: true}
+		liveview.NewDriver("inspector_"+targetID, inspector)
+		return inspector
+	}
+}
+
+func (t *Inspector) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Inspector) Start() {
+	if t.MaxEvents == 0 {
+		t.MaxEvents = 50
+	}
+	if t.Container != "" {
+		t.AppendHTML(t.Container, `<div id="`+html.EscapeString(t.GetID())+`"></div>`)
+	}
+	liveview.EnableDebugEvents()
+	t.mu.Lock()
+	if t.cancels == nil {
+		bus := liveview.Bus()
+		t.cancels = []func(){
+			bus.Subscribe(t.DebugTopic("event", t.TargetComponentID), t.onEvent),
+			bus.Subscribe(t.DebugTopic("commit", t.TargetComponentID), t.onCommit),
+		}
+	}
+	t.mu.Unlock()
+	t.Commit()
+}
+
+func (t *Inspector) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="inspector" style="border-bottom:1px solid #ccc;font:12px monospace">
+	<div style="background:#eee;padding:4px;cursor:pointer" onclick="send_event('{{.IdComponent}}', 'Toggle')">{{if .Collapsed}}&#9656;{{else}}&#9662;{{end}} {{html .TargetComponentID}}</div>
+	<div id="{{.IdComponent}}_body" style="padding:4px{{if .Collapsed}};display:none{{end}}">{{.RenderBody}}</div>
+</div>`
+}
+
+func (t *Inspector) onEvent(payload interface{}) {
+	e, ok := payload.(liveview.DebugEvent)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	t.events = append(t.events, InspectorEntry{Time: e.Time, Name: e.Name, Data: fmt.Sprint(e.Data)})
+	if t.MaxEvents > 0 && len(t.events) > t.MaxEvents {
+		t.events = t.events[len(t.events)-t.MaxEvents:]
+	}
+	t.mu.Unlock()
+	t.refresh()
+}
+
+func (t *Inspector) onCommit(payload interface{}) {
+	c, ok := payload.(liveview.DebugCommit)
+	if !ok {
+		return
+	}
+	t.mu.Lock()
+	t.commitCount++
+	t.lastRender = c.Duration
+	t.mu.Unlock()
+	t.refresh()
+}
+
+// refresh update only the body, so the inspector does not Commit for each change of target
+func (t *Inspector) refresh() {
+	t.mu.Lock()
+	collapsed := t.Collapsed
+	t.mu.Unlock()
+	if collapsed {
+		return
+	}
+	t.FillValueById(t.IdComponent+"_body", t.RenderBody())
+}
+
+func inspectorValue(v reflect.Value) string {
+	text := fmt.Sprintf("%+v", v.Interface())
+	if len(text) > 200 {
+		text = text[:200] + "…"
+	}
+	return text
+}
+
+// RenderBody return the html of state, render stats and event log of target
+func (t *Inspector) RenderBody() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sb := &strings.Builder{}
+	if t.ShowState {
+		sb.WriteString(`<details open><summary>State</summary><table>`)
+		var target liveview.LiveDriver
+		if t.ComponentDriver != nil {
+			target = t.FindDriver(t.TargetComponentID)
+		}
+		if target != nil {
+			v := reflect.Indirect(reflect.ValueOf(target.GetComponet()))
+			for i := 0; i < v.NumField(); i++ {
+				field := v.Type().Field(i)
+				if !field.IsExported() || field.Anonymous || field.Type.Kind() == reflect.Func {
+					continue
+				}
+				fmt.Fprintf(sb, `<tr><td style="color:#6a1b9a;vertical-align:top">%s</td><td>%s</td></tr>`,
+					html.EscapeString(field.Name), html.EscapeString(inspectorValue(v.Field(i))))
+			}
+		}
+		sb.WriteString(`</table></details>`)
+	}
+	if t.ShowRenderStats {
+		fmt.Fprintf(sb, `<details open><summary>Render</summary>Commits: %d<br/>Last render: %s</details>`, t.commitCount, t.lastRender)
+	}
+	if t.ShowEventLog {
+		sb.WriteString(`<details open><summary>Events</summary>`)
+		for i := len(t.events) - 1; i >= 0; i-- {
+			e := t.events[i]
+			fmt.Fprintf(sb, `<div><span style="color:gray">%s</span> <strong>%s</strong> %s</div>`,
+				e.Time.Format("15:04:05.000"), html.EscapeString(e.Name), html.EscapeString(e.Data))
+		}
+		sb.WriteString(`</details>`)
+	}
+	return sb.String()
+}
+
+// Events return a copy of the event log of target
+func (t *Inspector) Events() []InspectorEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]InspectorEntry(nil), t.events...)
+}
+
+// CommitCount return the count of commits of target and the duration of the last render
+func (t *Inspector) CommitCount() (int, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.commitCount, t.lastRender
+}
+
+func (t *Inspector) Toggle(data interface{}) {
+	t.mu.Lock()
+	t.Collapsed = !t.Collapsed
+	t.mu.Unlock()
+	t.Commit()
+}
+
+// Close cancel the subscriptions of bus
+func (t *Inspector) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, cancel := range t.cancels {
+		cancel()
+	}
+	t.cancels = nil
+}
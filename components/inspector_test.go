@@ -0,0 +1,51 @@
+package components
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+func TestInspectorConcurrentLog(t *testing.T) {
+	inspector := &Inspector{TargetComponentID: "target", ShowEventLog: true, ShowRenderStats: true, MaxEvents: 5}
+	startComponent("inspector_target", inspector)
+	defer inspector.Close()
+	inspector.Memo = true
+	bus := liveview.Bus()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			bus.Publish(inspector.DebugTopic("event", "target"), liveview.DebugEvent{ComponentID: "target", Name: fmt.Sprint("Click", i), Time: time.Now()})
+			bus.Publish(inspector.DebugTopic("commit", "target"), liveview.DebugCommit{ComponentID: "target", Duration: time.Millisecond})
+		}
+	}()
+	// the render and the memo of inspector run while the bus delivers the log
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			inspector.Commit()
+		}
+	}()
+	wg.Wait()
+	deadline := time.Now().Add(time.Second)
+	for {
+		count, _ := inspector.CommitCount()
+		events := inspector.Events()
+		if (count == 50 && len(events) == 5 && events[4].Name == "Click49") || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if count, last := inspector.CommitCount(); count != 50 || last != time.Millisecond {
+		t.Errorf("CommitCount = %d, %s, want 50, 1ms", count, last)
+	}
+	events := inspector.Events()
+	if len(events) != 5 || events[4].Name != "Click49" {
+		t.Errorf("Events = %v, want the last 5", events)
+	}
+}
@@ -0,0 +1,308 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type JSONViewer struct {
+	*liveview.ComponentDriver[*JSONViewer]
+	// Data is any value serializable to JSON
+	Data interface{}
+	// InitialDepth is the depth expanded at start (default 2)
+	InitialDepth int
+	// MaxDepth limit the depth rendered, 0 is without limit
+	MaxDepth    int
+	SearchQuery string
+	// OnValueClick receive the JSON Pointer of clicked leaf value
+	OnValueClick func(path string, value interface{})
+	// Expanded keep the nodes toggled by the user by JSON Pointer
+	Expanded map[string]bool
+	Error    string
+	value    interface{}
+}
+
+func (t *JSONViewer) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *JSONViewer) Start() {
+	if t.InitialDepth == 0 {
+		t.InitialDepth = 2
+	}
+	if t.Expanded == nil {
+		t.Expanded = make(map[string]bool)
+	}
+	t.normalize()
+	t.Commit()
+}
+
+func (t *JSONViewer) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="json-viewer" style="font-family:monospace;font-size:13px">
+	<input type="search" placeholder="Search..." value="{{html .SearchQuery}}"
+		oninput="clearTimeout(this._t); var v = this.value; this._t = setTimeout(function(){ send_event('{{.IdComponent}}', 'Search', v) }, 300)"/>
+	{{if .Error}}<div class="json-viewer-error" style="color:red">{{html .Error}}</div>{{end}}
+	<div id="{{.IdComponent}}_tree">{{.RenderTree}}</div>
+</div>`
+}
+
+// normalize convert Data to the generic values of encoding/json (map, slice, string, float64, bool and nil)
+func (t *JSONViewer) normalize() {
+	t.Error = ""
+	b, err := json.Marshal(t.Data)
+	if err != nil {
+		t.Error = err.Error()
+		t.value = nil
+		return
+	}
+	json.Unmarshal(b, &t.value)
+}
+
+func jsonPointerEscape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// Lookup return the value of JSON Pointer path
+func (t *JSONViewer) Lookup(path string) (interface{}, bool) {
+	value := t.value
+	if path == "" {
+		return value, true
+	}
+	for _, part := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		switch v := value.(type) {
+		case map[string]interface{}:
+			next, ok := v[part]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func (t *JSONViewer) matches(text string) bool {
+	start, _ := jsonFoldIndex(text, t.SearchQuery)
+	return start >= 0
+}
+
+// jsonFoldIndex return the bytes of text where query is found without case, the case is folded rune by rune on text
+// (the lowercase of some runes has other length, so the indices of strings.ToLower(text) are not the indices of text)
+func jsonFoldIndex(text, query string) (int, int) {
+	if query == "" {
+		return -1, -1
+	}
+	for start := range text {
+		end := start
+		for _, q := range query {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if size == 0 || !strings.EqualFold(string(r), string(q)) {
+				end = -1
+				break
+			}
+			end += size
+		}
+		if end >= 0 {
+			return start, end
+		}
+	}
+	return -1, -1
+}
+
+// matchPaths return the paths that contain a match (the ancestors of matches), they are expanded on search
+func (t *JSONViewer) matchPaths() map[string]bool {
+	paths := make(map[string]bool)
+	if t.SearchQuery == "" {
+		return paths
+	}
+	var walk func(path string, key string, value interface{}) bool
+	walk = func(path string, key string, value interface{}) bool {
+		found := t.matches(key)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for k, child := range v {
+				if walk(path+"/"+jsonPointerEscape(k), k, child) {
+					found = true
+					paths[path] = true
+				}
+			}
+		case []interface{}:
+			for i, child := range v {
+				if walk(path+"/"+strconv.Itoa(i), "", child) {
+					found = true
+					paths[path] = true
+				}
+			}
+		default:
+			found = found || t.matches(jsonScalar(v))
+		}
+		return found
+	}
+	walk("", "", t.value)
+	return paths
+}
+
+func (t *JSONViewer) isExpanded(path string, depth int, matches map[string]bool) bool {
+	if expanded, ok := t.Expanded[path]; ok {
+		return expanded
+	}
+	return depth < t.InitialDepth || matches[path]
+}
+
+func jsonScalar(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+var jsonColors = map[string]string{"string": "#2e7d32", "number": "#1565c0", "bool": "#ef6c00", "null": "#9e9e9e"}
+
+func (t *JSONViewer) highlight(text string) string {
+	start, end := jsonFoldIndex(text, t.SearchQuery)
+	if start < 0 {
+		return html.EscapeString(text)
+	}
+	return html.EscapeString(text[:start]) + "<mark>" + html.EscapeString(text[start:end]) + "</mark>" + html.EscapeString(text[end:])
+}
+
+// RenderTree return the nested ul of Data
+func (t *JSONViewer) RenderTree() string {
+	sb := &strings.Builder{}
+	sb.WriteString(`<ul style="list-style:none;padding-left:0;margin:0">`)
+	t.renderValue(sb, "", "", t.value, 0, t.matchPaths())
+	sb.WriteString(`</ul>`)
+	return sb.String()
+}
+
+func (t *JSONViewer) renderValue(sb *strings.Builder, path string, key string, value interface{}, depth int, matches map[string]bool) {
+	sb.WriteString(`<li>`)
+	label := ""
+	if key != "" {
+		label = `<span style="color:#6a1b9a">` + t.highlight(key) + `</span>: `
+	}
+	// the path is a js string inside the attribute
+	escapedPath := html.EscapeString(template.JSEscapeString(path))
+	var children []string
+	var childValues []interface{}
+	open, close := "", ""
+	switch v := value.(type) {
+	case map[string]interface{}:
+		open, close = "{", "}"
+		for k := range v {
+			children = append(children, k)
+		}
+		sort.Strings(children)
+		for _, k := range children {
+			childValues = append(childValues, v[k])
+		}
+	case []interface{}:
+		open, close = "[", "]"
+		for i, child := range v {
+			children = append(children, strconv.Itoa(i))
+			childValues = append(childValues, child)
+		}
+	default:
+		kind := "string"
+		switch v.(type) {
+		case float64:
+			kind = "number"
+		case bool:
+			kind = "bool"
+		case nil:
+			kind = "null"
+		}
+		fmt.Fprintf(sb, `%s<span style="color:%s;cursor:pointer" onclick="send_event('%s','ValueClick','%s')">%s</span>`,
+			label, jsonColors[kind], t.IdComponent, escapedPath, t.highlight(jsonScalar(v)))
+		fmt.Fprintf(sb, ` <button title="Copy" style="font-size:10px" onclick="send_event('%s','CopyValue','%s')">&#10697;</button></li>`, t.IdComponent, escapedPath)
+		return
+	}
+	if t.MaxDepth > 0 && depth >= t.MaxDepth {
+		fmt.Fprintf(sb, `%s%s&hellip;%s</li>`, label, open, close)
+		return
+	}
+	expanded := t.isExpanded(path, depth, matches)
+	arrow := "&#9656;"
+	if expanded {
+		arrow = "&#9662;"
+	}
+	fmt.Fprintf(sb, `<span style="cursor:pointer" onclick="send_event('%s','Toggle','%s')">%s</span> %s%s`, t.IdComponent, escapedPath, arrow, label, open)
+	if !expanded {
+		fmt.Fprintf(sb, `<span style="color:gray">&hellip; %d</span>%s`, len(children), close)
+	} else {
+		fmt.Fprintf(sb, ` <button title="Copy" style="font-size:10px" onclick="send_event('%s','CopyValue','%s')">&#10697;</button>`, t.IdComponent, escapedPath)
+		sb.WriteString(`<ul style="list-style:none;padding-left:16px;margin:0">`)
+		for i, k := range children {
+			childKey := k
+			if open == "[" {
+				childKey = ""
+			}
+			t.renderValue(sb, path+"/"+jsonPointerEscape(k), childKey, childValues[i], depth+1, matches)
+		}
+		sb.WriteString(`</ul>` + close)
+	}
+	sb.WriteString(`</li>`)
+}
+
+// SetData change Data and Commit, the expanded nodes are reset
+func (t *JSONViewer) SetData(v interface{}) {
+	t.Data = v
+	t.Expanded = make(map[string]bool)
+	t.normalize()
+	t.Commit()
+}
+
+func (t *JSONViewer) Toggle(data interface{}) {
+	path := fmt.Sprint(data)
+	if t.Expanded == nil {
+		t.Expanded = make(map[string]bool)
+	}
+	depth := 0
+	if path != "" {
+		depth = strings.Count(path, "/")
+	}
+	t.Expanded[path] = !t.isExpanded(path, depth, t.matchPaths())
+	t.FillValueById(t.IdComponent+"_tree", t.RenderTree())
+}
+
+func (t *JSONViewer) Search(data interface{}) {
+	t.SearchQuery = fmt.Sprint(data)
+	// the toggles are reset for expand the paths to the matches
+	t.Expanded = make(map[string]bool)
+	t.FillValueById(t.IdComponent+"_tree", t.RenderTree())
+}
+
+func (t *JSONViewer) ValueClick(data interface{}) {
+	path := fmt.Sprint(data)
+	if value, ok := t.Lookup(path); ok && t.OnValueClick != nil {
+		t.OnValueClick(path, value)
+	}
+}
+
+func (t *JSONViewer) CopyValue(data interface{}) {
+	value, ok := t.Lookup(fmt.Sprint(data))
+	if !ok {
+		return
+	}
+	if s, ok := value.(string); ok {
+		t.CopyToClipboard(s)
+		return
+	}
+	b, _ := json.MarshalIndent(value, "", "  ")
+	t.CopyToClipboard(string(b))
+}
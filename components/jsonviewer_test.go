@@ -0,0 +1,24 @@
+package components
+
+import "testing"
+
+func TestJSONViewerHighlight(t *testing.T) {
+	tests := []struct {
+		text, query string
+		want        string
+	}{
+		{"hello world", "WORLD", "hello <mark>world</mark>"},
+		{"no match", "xyz", "no match"},
+		// the lowercase of İ has 3 bytes and of K (kelvin) has 1 byte
+		{"İİİ abc", "abc", "İİİ <mark>abc</mark>"},
+		{"\u212a <b>", "k <B", "<mark>\u212a &lt;b</mark>&gt;"},
+		{"ÀÉÎ straße", "éî", "À<mark>ÉÎ</mark> straße"},
+		{"ab", "abc", "ab"},
+	}
+	for _, tt := range tests {
+		jv := &JSONViewer{SearchQuery: tt.query}
+		if got := jv.highlight(tt.text); got != tt.want {
+			t.Errorf("highlight(%q, %q) = %q, want %q", tt.text, tt.query, got, tt.want)
+		}
+	}
+}
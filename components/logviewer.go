@@ -0,0 +1,162 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type LogLine struct {
+	Timestamp time.Time
+	Level     string
+	Message   string
+	Source    string
+}
+
+var logLevels = map[string]int{"debug": 0, "info": 1, "warn": 2, "warning": 2, "error": 3, "fatal": 4}
+
+var logColors = map[string]string{"debug": "#888", "info": "#8bc34a", "warn": "#ffc107", "warning": "#ffc107", "error": "#f44336", "fatal": "#f44336"}
+
+type LogViewer struct {
+	*liveview.ComponentDriver[*LogViewer]
+	// MaxLines is the size of buffer (default 1000)
+	MaxLines   int
+	LogLines   []LogLine
+	AutoScroll bool
+	// FilterLevel hide the lines with level lower (debug, info, warn, error)
+	FilterLevel string
+	Height      string
+	mu          sync.Mutex
+	first       int
+}
+
+func (t *LogViewer) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *LogViewer) Start() {
+	if t.MaxLines == 0 {
+		t.MaxLines = 1000
+	}
+	if t.Height == "" {
+		t.Height = "300px"
+	}
+	t.Commit()
+}
+
+func (t *LogViewer) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="log-viewer">
+	<div>
+		<input type="search" placeholder="Search..."
+			oninput="var q = this.value.toLowerCase(); document.querySelectorAll('#{{.IdComponent}}_lines > div').forEach(function(e){ e.style.display = e.textContent.toLowerCase().indexOf(q) >= 0 ? '' : 'none' })"/>
+		<select onchange="send_event('{{.IdComponent}}', 'SetFilterLevel', this.value)">
+			<option value="" {{if eq .FilterLevel ""}}selected{{end}}>all</option>
+			<option value="info" {{if eq .FilterLevel "info"}}selected{{end}}>info</option>
+			<option value="warn" {{if eq .FilterLevel "warn"}}selected{{end}}>warn</option>
+			<option value="error" {{if eq .FilterLevel "error"}}selected{{end}}>error</option>
+		</select>
+		<button onclick="send_event('{{.IdComponent}}', 'Copy')">Copy to clipboard</button>
+		<button onclick="send_event('{{.IdComponent}}', 'Download')">Download as .txt</button>
+	</div>
+	<pre id="{{.IdComponent}}_lines" style="height:{{.Height}};overflow:auto;background:#1e1e1e;color:#ddd;margin:0;padding:4px">{{.RenderLines}}</pre>
+</div>`
+}
+
+func (t *LogViewer) visible(line LogLine) bool {
+	return logLevels[strings.ToLower(line.Level)] >= logLevels[strings.ToLower(t.FilterLevel)]
+}
+
+func (t *LogViewer) renderLine(i int, line LogLine) string {
+	level := strings.ToLower(line.Level)
+	source := ""
+	if line.Source != "" {
+		source = " [" + html.EscapeString(line.Source) + "]"
+	}
+	return fmt.Sprintf(`<div id="%s_line_%d"><span style="color:#888">%s</span> <span style="color:%s">%-5s</span>%s %s</div>`,
+		t.IdComponent, i, line.Timestamp.Format("15:04:05.000"), logColors[level], html.EscapeString(strings.ToUpper(level)), source, ansiToHTML(line.Message))
+}
+
+// RenderLines return the html of lines with level >= FilterLevel
+func (t *LogViewer) RenderLines() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sb := &strings.Builder{}
+	for i, line := range t.LogLines {
+		if t.visible(line) {
+			sb.WriteString(t.renderLine(t.first+i, line))
+		}
+	}
+	return sb.String()
+}
+
+// AppendLine add line to buffer and append it to the page without render all lines
+func (t *LogViewer) AppendLine(line LogLine) {
+	if line.Timestamp.IsZero() {
+		line.Timestamp = time.Now()
+	}
+	t.mu.Lock()
+	t.LogLines = append(t.LogLines, line)
+	index := t.first + len(t.LogLines) - 1
+	removed := make([]int, 0)
+	for t.MaxLines > 0 && len(t.LogLines) > t.MaxLines {
+		removed = append(removed, t.first)
+		t.LogLines = t.LogLines[1:]
+		t.first++
+	}
+	t.mu.Unlock()
+
+	for _, i := range removed {
+		t.Remove(fmt.Sprintf("%s_line_%d", t.IdComponent, i))
+	}
+	if t.visible(line) {
+		t.AppendHTML(t.IdComponent+"_lines", t.renderLine(index, line))
+	}
+	if t.AutoScroll {
+		t.EvalScript(fmt.Sprintf(`var e = document.getElementById("%s_lines"); if (e) { e.scrollTop = e.scrollHeight; }`, t.IdComponent))
+	}
+}
+
+// Log append line with level and message
+func (t *LogViewer) Log(level string, message string) {
+	t.AppendLine(LogLine{Level: level, Message: message})
+}
+
+// Text return the lines as plain text
+func (t *LogViewer) Text() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sb := &strings.Builder{}
+	for _, line := range t.LogLines {
+		fmt.Fprintf(sb, "%s %-5s", line.Timestamp.Format(time.RFC3339), strings.ToUpper(line.Level))
+		if line.Source != "" {
+			fmt.Fprintf(sb, " [%s]", line.Source)
+		}
+		fmt.Fprintf(sb, " %s\n", ansiRegexp.ReplaceAllString(line.Message, ""))
+	}
+	return sb.String()
+}
+
+func (t *LogViewer) Clear() {
+	t.mu.Lock()
+	t.first += len(t.LogLines)
+	t.LogLines = nil
+	t.mu.Unlock()
+	t.Commit()
+}
+
+func (t *LogViewer) SetFilterLevel(data interface{}) {
+	t.FilterLevel = fmt.Sprint(data)
+	t.Commit()
+}
+
+func (t *LogViewer) Copy(data interface{}) {
+	t.CopyToClipboard(t.Text())
+}
+
+func (t *LogViewer) Download(data interface{}) {
+	t.DownloadFile("log.txt", "text/plain", t.Text())
+}
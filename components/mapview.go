@@ -0,0 +1,186 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type MapMarker struct {
+	ID    string  `json:"id"`
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+	Label string  `json:"label"`
+	// Icon is the url of image of marker
+	Icon string `json:"icon"`
+	// Color draws the marker as a circle of this color
+	Color string `json:"color"`
+}
+
+// MapView is a Leaflet map, the map is created by script after the first Commit and the next Commits only send the
+// changes of markers, center, zoom and GeoJSON (the div of map is not rendered again)
+type MapView struct {
+	*liveview.ComponentDriver[*MapView]
+	Center  [2]float64
+	Zoom    int
+	Markers []MapMarker
+	GeoJSON string
+	// CDN is the base url of leaflet.js and leaflet.css (default unpkg leaflet 1.9.4)
+	CDN string
+	// TileURL is the url template of tiles (default OpenStreetMap)
+	TileURL       string
+	Height        string
+	OnMarkerClick func(id string)
+	OnMapClick    func(lat, lon float64)
+	initialized   bool
+	rendered      map[string]MapMarker
+	view          string
+	geoJSON       string
+}
+
+func (t *MapView) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *MapView) Start() {
+	if t.Zoom == 0 {
+		t.Zoom = 13
+	}
+	if t.CDN == "" {
+		t.CDN = "https://unpkg.com/leaflet@1.9.4/dist/"
+	}
+	if t.TileURL == "" {
+		t.TileURL = "https://{s}.tile.openstreetmap.org/{z}/{x}/{y}.png"
+	}
+	if t.Height == "" {
+		t.Height = "400px"
+	}
+	// Start is called again on reconnect, the map is created again
+	t.initialized = false
+	t.Commit()
+}
+
+func (t *MapView) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="map-view"><div id="map-{{.IdComponent}}" style="height:{{.Height}}"></div></div>`
+}
+
+func jsJSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// Commit render the map the first time and then send the changes with scripts
+func (t *MapView) Commit() {
+	if !t.initialized {
+		t.ComponentDriver.Commit()
+		t.rendered = make(map[string]MapMarker)
+		t.view, t.geoJSON = "", ""
+		t.EvalScript(t.initScript())
+		t.initialized = true
+	}
+	if script := t.updateScript(); script != "" {
+		t.EvalScript(fmt.Sprintf("liveviewMapRun(%s, function(s) {\n%s})", jsJSON(t.IdComponent), script))
+	}
+}
+
+// initScript load leaflet (once per page) and create the map, the scripts of liveviewMapRun wait for it
+func (t *MapView) initScript() string {
+	return fmt.Sprintf(`(function() {
+	var id = %s, cdn = %s, tiles = %s;
+	window.liveviewMaps = window.liveviewMaps || {};
+	window.liveviewMapRun = window.liveviewMapRun || function(id, fn) {
+		var s = window.liveviewMaps[id];
+		if (s && s.map) { fn(s); } else if (s) { s.queue.push(fn); }
+	};
+	var old = window.liveviewMaps[id];
+	if (old && old.map) { old.map.remove(); }
+	var state = window.liveviewMaps[id] = { map: null, markers: {}, geojson: null, queue: [] };
+	function init() {
+		var map = L.map("map-" + id);
+		L.tileLayer(tiles, { attribution: "&copy; OpenStreetMap contributors" }).addTo(map);
+		map.on("click", function(e) { send_event(id, "MapClick", JSON.stringify({ lat: e.latlng.lat, lon: e.latlng.lng })); });
+		state.map = map;
+		state.queue.splice(0).forEach(function(fn) { fn(state); });
+	}
+	if (window.L) { init(); return; }
+	if (!window.liveviewLeaflet) {
+		window.liveviewLeaflet = new Promise(function(resolve) {
+			var css = document.createElement("link");
+			css.rel = "stylesheet";
+			css.href = cdn + "leaflet.css";
+			document.head.appendChild(css);
+			var script = document.createElement("script");
+			script.src = cdn + "leaflet.js";
+			script.onload = resolve;
+			document.head.appendChild(script);
+		});
+	}
+	window.liveviewLeaflet.then(init);
+})();`, jsJSON(t.IdComponent), jsJSON(t.CDN), jsJSON(t.TileURL))
+}
+
+// updateScript return the script with the differences between the fields and the map in the browser
+func (t *MapView) updateScript() string {
+	sb := &strings.Builder{}
+	view := jsJSON([]interface{}{t.Center, t.Zoom})
+	if view != t.view {
+		fmt.Fprintf(sb, "s.map.setView(%s, %d);\n", jsJSON(t.Center), t.Zoom)
+		t.view = view
+	}
+	current := make(map[string]bool)
+	for _, m := range t.Markers {
+		current[m.ID] = true
+		// leaflet renders the tooltip as html
+		if old, ok := t.rendered[m.ID]; ok && old == m {
+			continue
+		}
+		fmt.Fprintf(sb, `(function(m) {
+	if (s.markers[m.id]) { s.markers[m.id].remove(); }
+	var marker;
+	if (m.icon) { marker = L.marker([m.lat, m.lon], { icon: L.icon({ iconUrl: m.icon, iconSize: [24, 24] }) }); }
+	else if (m.color) { marker = L.circleMarker([m.lat, m.lon], { color: m.color, fillColor: m.color, fillOpacity: 0.8, radius: 8 }); }
+	else { marker = L.marker([m.lat, m.lon]); }
+	if (m.label) { marker.bindTooltip(%s); }
+	marker.on("click", function() { send_event(%s, "MarkerClick", m.id); });
+	s.markers[m.id] = marker.addTo(s.map);
+})(%s);
+`, jsJSON(html.EscapeString(m.Label)), jsJSON(t.IdComponent), jsJSON(m))
+		t.rendered[m.ID] = m
+	}
+	for id := range t.rendered {
+		if !current[id] {
+			fmt.Fprintf(sb, "if (s.markers[%[1]s]) { s.markers[%[1]s].remove(); delete s.markers[%[1]s]; }\n", jsJSON(id))
+			delete(t.rendered, id)
+		}
+	}
+	if t.GeoJSON != t.geoJSON {
+		sb.WriteString("if (s.geojson) { s.geojson.remove(); s.geojson = null; }\n")
+		if t.GeoJSON != "" {
+			fmt.Fprintf(sb, "s.geojson = L.geoJSON(JSON.parse(%s)).addTo(s.map);\n", jsJSON(t.GeoJSON))
+		}
+		t.geoJSON = t.GeoJSON
+	}
+	return sb.String()
+}
+
+func (t *MapView) MarkerClick(data interface{}) {
+	if t.OnMarkerClick != nil {
+		t.OnMarkerClick(fmt.Sprint(data))
+	}
+}
+
+func (t *MapView) MapClick(data interface{}) {
+	var point struct {
+		Lat float64 `json:"lat"`
+		Lon float64 `json:"lon"`
+	}
+	if err := json.Unmarshal([]byte(fmt.Sprint(data)), &point); err != nil {
+		return
+	}
+	if t.OnMapClick != nil {
+		t.OnMapClick(point.Lat, point.Lon)
+	}
+}
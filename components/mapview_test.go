@@ -0,0 +1,21 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+func TestMapViewTooltipEscaped(t *testing.T) {
+	m := &MapView{rendered: make(map[string]MapMarker)}
+	liveview.NewDriver("map", m)
+	m.Markers = []MapMarker{{ID: "a", Label: `<img src=x onerror="alert(1)">`}}
+	script := m.updateScript()
+	if strings.Contains(script, "bindTooltip(m.label)") || strings.Contains(script, `bindTooltip("\u003cimg`) {
+		t.Errorf("updateScript binds the raw label: %s", script)
+	}
+	if !strings.Contains(script, `bindTooltip("\u0026lt;img src=x onerror=\u0026#34;alert(1)\u0026#34;\u0026gt;")`) {
+		t.Errorf("updateScript does not bind the escaped label: %s", script)
+	}
+}
@@ -0,0 +1,240 @@
+package components
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// markdown converter for the common syntax: headings, paragraphs, emphasis, code, blockquotes, lists, rules,
+// links and images, the plugins "tables", "strikethrough" and "autolink" enable the extensions of GFM
+
+var (
+	mdHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdRule        = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	mdUnordered   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrdered     = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	mdFence       = regexp.MustCompile("^\\s*(```|~~~)\\s*([\\w+-]*)")
+	mdTableSep    = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdCode        = regexp.MustCompile("`([^`]+)`")
+	mdImage       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"([^"]*)")?\)`)
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+"([^"]*)")?\)`)
+	mdStrong      = regexp.MustCompile(`(\*\*|__)(.+?)(\*\*|__)`)
+	mdEmphasis    = regexp.MustCompile(`(^|[^\w*])[*_]([^*_]+)[*_]`)
+	mdStrike      = regexp.MustCompile(`~~(.+?)~~`)
+	mdAutolink    = regexp.MustCompile(`(^|[\s(])(https?://[^\s<)]+)`)
+	mdPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+type markdownConverter struct {
+	sanitize bool
+	plugins  map[string]bool
+	sb       strings.Builder
+}
+
+// markdownToHTML convert src to html, if sanitize is true the raw html is escaped and the links with unsafe schemes are removed
+func markdownToHTML(src string, sanitize bool, plugins []string) string {
+	c := &markdownConverter{sanitize: sanitize, plugins: make(map[string]bool)}
+	for _, p := range plugins {
+		c.plugins[strings.ToLower(p)] = true
+	}
+	// NUL marks the placeholders of inline, in the source it is replaced like CommonMark
+	src = strings.ReplaceAll(strings.ReplaceAll(src, "\r\n", "\n"), "\x00", "\uFFFD")
+	c.blocks(strings.Split(src, "\n"))
+	return c.sb.String()
+}
+
+func (c *markdownConverter) blocks(lines []string) {
+	paragraph := make([]string, 0)
+	flush := func() {
+		if len(paragraph) > 0 {
+			c.sb.WriteString("<p>" + c.inline(strings.Join(paragraph, "\n")) + "</p>\n")
+			paragraph = paragraph[:0]
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case mdFence.MatchString(line):
+			flush()
+			m := mdFence.FindStringSubmatch(line)
+			code := make([]string, 0)
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if m[2] != "" {
+				class = ` class="language-` + html.EscapeString(m[2]) + `"`
+			}
+			c.sb.WriteString("<pre><code" + class + ">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case mdHeading.MatchString(trimmed):
+			flush()
+			m := mdHeading.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(m[1])))
+			c.sb.WriteString("<h" + level + ">" + c.inline(m[2]) + "</h" + level + ">\n")
+		case mdRule.MatchString(line):
+			flush()
+			c.sb.WriteString("<hr/>\n")
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			quote := make([]string, 0)
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"), " "))
+			}
+			i--
+			c.sb.WriteString("<blockquote>\n")
+			c.blocks(quote)
+			c.sb.WriteString("</blockquote>\n")
+		case mdUnordered.MatchString(line) || mdOrdered.MatchString(line):
+			flush()
+			re, tag := mdUnordered, "ul"
+			if !mdUnordered.MatchString(line) {
+				re, tag = mdOrdered, "ol"
+			}
+			c.sb.WriteString("<" + tag + ">\n")
+			for ; i < len(lines) && re.MatchString(lines[i]); i++ {
+				c.sb.WriteString("<li>" + c.inline(re.FindStringSubmatch(lines[i])[1]) + "</li>\n")
+			}
+			i--
+			c.sb.WriteString("</" + tag + ">\n")
+		case c.plugins["tables"] && strings.Contains(line, "|") && i+1 < len(lines) && mdTableSep.MatchString(lines[i+1]):
+			flush()
+			c.table(lines, &i)
+		default:
+			paragraph = append(paragraph, strings.TrimLeft(line, " \t"))
+		}
+	}
+	flush()
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(strings.TrimSuffix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+func (c *markdownConverter) table(lines []string, i *int) {
+	header := splitTableRow(lines[*i])
+	aligns := make([]string, len(header))
+	for j, sep := range splitTableRow(lines[*i+1]) {
+		if j >= len(aligns) {
+			break
+		}
+		left, right := strings.HasPrefix(sep, ":"), strings.HasSuffix(sep, ":")
+		switch {
+		case left && right:
+			aligns[j] = ` style="text-align:center"`
+		case right:
+			aligns[j] = ` style="text-align:right"`
+		case left:
+			aligns[j] = ` style="text-align:left"`
+		}
+	}
+	c.sb.WriteString("<table>\n<thead><tr>")
+	for j, cell := range header {
+		c.sb.WriteString("<th" + aligns[j] + ">" + c.inline(cell) + "</th>")
+	}
+	c.sb.WriteString("</tr></thead>\n<tbody>\n")
+	for *i += 2; *i < len(lines) && strings.Contains(lines[*i], "|") && strings.TrimSpace(lines[*i]) != ""; *i++ {
+		c.sb.WriteString("<tr>")
+		cells := splitTableRow(lines[*i])
+		for j := range header {
+			cell := ""
+			if j < len(cells) {
+				cell = cells[j]
+			}
+			c.sb.WriteString("<td" + aligns[j] + ">" + c.inline(cell) + "</td>")
+		}
+		c.sb.WriteString("</tr>\n")
+	}
+	*i--
+	c.sb.WriteString("</tbody>\n</table>\n")
+}
+
+// safeURL return "" for urls with schemes that can run scripts
+func (c *markdownConverter) safeURL(url string) string {
+	if !c.sanitize {
+		return url
+	}
+	lower := strings.ToLower(strings.TrimSpace(url))
+	if i := strings.Index(lower, ":"); i >= 0 && !strings.ContainsAny(lower[:i], "/?#") {
+		scheme := lower[:i]
+		if scheme != "http" && scheme != "https" && scheme != "mailto" && scheme != "tel" {
+			return ""
+		}
+	}
+	return url
+}
+
+// inline convert the inline syntax, the code spans and links are replaced by placeholders so their content is not converted
+func (c *markdownConverter) inline(text string) string {
+	parts := make([]string, 0)
+	hold := func(s string) string {
+		parts = append(parts, s)
+		return "\x00" + strconv.Itoa(len(parts)-1) + "\x00"
+	}
+	text = mdCode.ReplaceAllStringFunc(text, func(s string) string {
+		return hold("<code>" + html.EscapeString(mdCode.FindStringSubmatch(s)[1]) + "</code>")
+	})
+	text = mdImage.ReplaceAllStringFunc(text, func(s string) string {
+		m := mdImage.FindStringSubmatch(s)
+		title := ""
+		if m[3] != "" {
+			title = ` title="` + html.EscapeString(m[3]) + `"`
+		}
+		return hold(`<img src="` + html.EscapeString(c.safeURL(m[2])) + `" alt="` + html.EscapeString(m[1]) + `"` + title + `/>`)
+	})
+	text = mdLink.ReplaceAllStringFunc(text, func(s string) string {
+		m := mdLink.FindStringSubmatch(s)
+		title := ""
+		if m[3] != "" {
+			title = ` title="` + html.EscapeString(m[3]) + `"`
+		}
+		return hold(`<a href="` + html.EscapeString(c.safeURL(m[2])) + `"` + title + `>` + c.emphasis(c.escape(m[1])) + `</a>`)
+	})
+	if c.plugins["autolink"] {
+		text = mdAutolink.ReplaceAllStringFunc(text, func(s string) string {
+			m := mdAutolink.FindStringSubmatch(s)
+			return m[1] + hold(`<a href="`+html.EscapeString(m[2])+`">`+html.EscapeString(m[2])+`</a>`)
+		})
+	}
+	text = c.emphasis(c.escape(text))
+	text = strings.ReplaceAll(text, "  \n", "<br/>\n")
+	// the parts can have placeholders (a code span in the text of link)
+	var expand func(text string) string
+	expand = func(text string) string {
+		return mdPlaceholder.ReplaceAllStringFunc(text, func(s string) string {
+			i, err := strconv.Atoi(mdPlaceholder.FindStringSubmatch(s)[1])
+			if err != nil || i >= len(parts) {
+				return ""
+			}
+			// a part only has placeholders of the previous parts
+			return expand(parts[i])
+		})
+	}
+	return expand(text)
+}
+
+func (c *markdownConverter) escape(text string) string {
+	if c.sanitize {
+		return html.EscapeString(text)
+	}
+	return text
+}
+
+func (c *markdownConverter) emphasis(text string) string {
+	text = mdStrong.ReplaceAllString(text, "<strong>$2</strong>")
+	text = mdEmphasis.ReplaceAllString(text, "$1<em>$2</em>")
+	if c.plugins["strikethrough"] {
+		text = mdStrike.ReplaceAllString(text, "<del>$1</del>")
+	}
+	return text
+}
@@ -0,0 +1,79 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownUnsafeLinks(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"[click](javascript:alert(1))", `<a href="">click</a>`},
+		{"[click](JavaScript:alert(1))", `<a href="">click</a>`},
+		{"[click](vbscript:msgbox)", `<a href="">click</a>`},
+		{"[click](data:text/html;base64,PHNjcmlwdD4=)", `<a href="">click</a>`},
+		{"[click](\x01javascript:alert(1))", `<a href="">click</a>`},
+		{"![x](javascript:alert(1))", `<img src="" alt="x"/>`},
+		{`[click](https://example.com "<b>'x'</b>")`, `<a href="https://example.com" title="&lt;b&gt;&#39;x&#39;&lt;/b&gt;">click</a>`},
+		{"[<b>x</b>](/docs)", `<a href="/docs">&lt;b&gt;x&lt;/b&gt;</a>`},
+		{"[mail](mailto:user@example.com)", `<a href="mailto:user@example.com">mail</a>`},
+		{"[page](/docs/a:b)", `<a href="/docs/a:b">page</a>`},
+	}
+	for _, tt := range tests {
+		got := markdownToHTML(tt.src, true, nil)
+		if !strings.Contains(got, tt.want) {
+			t.Errorf("markdownToHTML(%q) = %q, want it contains %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownRawHTML(t *testing.T) {
+	tests := []string{
+		"<script>alert(1)</script>",
+		"# <img src=x onerror=alert(1)>",
+		"> <iframe src=\"https://evil\"></iframe>",
+		"- <a href=\"javascript:alert(1)\">x</a>",
+		"**<svg onload=alert(1)>**",
+		"| a |\n|---|\n| <script>x</script> |",
+	}
+	for _, src := range tests {
+		got := markdownToHTML(src, true, []string{"tables", "autolink"})
+		for _, tag := range []string{"<script", "<img", "<iframe", "<svg", `<a href="javascript`} {
+			if strings.Contains(got, tag) {
+				t.Errorf("markdownToHTML(%q) = %q has %s", src, got, tag)
+			}
+		}
+		if !strings.Contains(got, "&lt;") {
+			t.Errorf("markdownToHTML(%q) = %q, want the html escaped", src, got)
+		}
+	}
+	// without sanitize the raw html is kept (trusted content)
+	if got := markdownToHTML("<b>x</b>", false, nil); !strings.Contains(got, "<b>x</b>") {
+		t.Errorf("markdownToHTML without sanitize = %q, want the raw html", got)
+	}
+}
+
+func TestMarkdownPlaceholders(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		// NUL in the source is not a placeholder
+		{"hi \x007\x00 there", "<p>hi \uFFFD7\uFFFD there</p>\n"},
+		{"\x000\x00 `code`", "<p>\uFFFD0\uFFFD <code>code</code></p>\n"},
+		// the code span in the text of link is expanded
+		{"[`x`](http://a)", `<p><a href="http://a"><code>x</code></a></p>` + "\n"},
+		{"[see `a<b>` and `c`](/docs) `d`", `<p><a href="/docs">see <code>a&lt;b&gt;</code> and <code>c</code></a> <code>d</code></p>` + "\n"},
+	}
+	for _, tt := range tests {
+		got := markdownToHTML(tt.src, true, []string{"autolink"})
+		if got != tt.want {
+			t.Errorf("markdownToHTML(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+		if strings.Contains(got, "\x00") {
+			t.Errorf("markdownToHTML(%q) = %q has a placeholder", tt.src, got)
+		}
+	}
+}
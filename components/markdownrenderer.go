@@ -0,0 +1,52 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type MarkdownRenderer struct {
+	*liveview.ComponentDriver[*MarkdownRenderer]
+	// Content is the raw markdown
+	Content string
+	// SanitizeHTML escape the raw html and remove unsafe links, Start set it to true unless DisableSanitize
+	// (only for trusted content)
+	SanitizeHTML    bool
+	DisableSanitize bool
+	// Plugins are the extensions "tables", "strikethrough" and "autolink"
+	Plugins []string
+	// OnLinkClick receive the href of clicked links instead of navigate
+	OnLinkClick func(href string)
+}
+
+func (t *MarkdownRenderer) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *MarkdownRenderer) Start() {
+	t.SanitizeHTML = !t.DisableSanitize
+	t.Commit()
+}
+
+func (t *MarkdownRenderer) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="markdown"{{if .OnLinkClick}}
+	onclick="var a = event.target.closest('a[href]'); if (a) { event.preventDefault(); send_event('{{.IdComponent}}', 'LinkClick', a.getAttribute('href')) }"{{end}}>{{.GetHTML}}</div>`
+}
+
+// GetHTML return the html of Content
+func (t *MarkdownRenderer) GetHTML() string {
+	return markdownToHTML(t.Content, t.SanitizeHTML, t.Plugins)
+}
+
+// SetContent change the markdown and Commit
+func (t *MarkdownRenderer) SetContent(md string) {
+	t.Content = md
+	t.Commit()
+}
+
+func (t *MarkdownRenderer) LinkClick(data interface{}) {
+	if t.OnLinkClick != nil {
+		t.OnLinkClick(fmt.Sprint(data))
+	}
+}
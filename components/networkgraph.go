@@ -0,0 +1,251 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"text/template"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type GraphNode struct {
+	ID    string
+	Label string
+	Color string
+	Size  int
+	X     float64
+	Y     float64
+}
+
+type GraphLink struct {
+	// ID is optional, the default is "Source-Target"
+	ID       string
+	Source   string
+	Target   string
+	Label    string
+	Width    int
+	Directed bool
+}
+
+type NetworkGraph struct {
+	*liveview.ComponentDriver[*NetworkGraph]
+	Nodes  []GraphNode
+	Links  []GraphLink
+	Width  int
+	Height int
+	// Iterations of the force layout before the first render (default 300)
+	Iterations int
+	// FixedLayout use X and Y of nodes and skip the force layout
+	FixedLayout bool
+	OnNodeClick func(id string)
+	OnLinkClick func(id string)
+	laidOut     bool
+}
+
+func (t *NetworkGraph) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *NetworkGraph) Start() {
+	if t.Width == 0 {
+		t.Width = 800
+	}
+	if t.Height == 0 {
+		t.Height = 600
+	}
+	if t.Iterations == 0 {
+		t.Iterations = 300
+	}
+	if !t.FixedLayout && !t.laidOut {
+		t.Layout()
+	}
+	t.Commit()
+}
+
+func (t *NetworkGraph) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="network-graph">
+	<svg id="{{.IdComponent}}_svg" data-panzoom width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}" style="border:1px solid #ddd;cursor:grab">
+		<defs>
+			<marker id="{{.IdComponent}}_arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse">
+				<path d="M 0 0 L 10 5 L 0 10 z" fill="#999"/>
+			</marker>
+		</defs>
+		{{.RenderLinks}}
+		{{.RenderNodes}}
+	</svg>
+</div>`
+}
+
+func (t *NetworkGraph) linkID(link GraphLink) string {
+	if link.ID != "" {
+		return link.ID
+	}
+	return link.Source + "-" + link.Target
+}
+
+func (t *NetworkGraph) nodeIndex() map[string]int {
+	index := make(map[string]int, len(t.Nodes))
+	for i, n := range t.Nodes {
+		index[n.ID] = i
+	}
+	return index
+}
+
+func nodeRadius(n GraphNode) float64 {
+	if n.Size > 0 {
+		return float64(n.Size)
+	}
+	return 10
+}
+
+// RenderLinks return the svg lines of links, the line ends at border of target node for show the arrow
+func (t *NetworkGraph) RenderLinks() string {
+	index := t.nodeIndex()
+	sb := &strings.Builder{}
+	for _, link := range t.Links {
+		si, ok1 := index[link.Source]
+		ti, ok2 := index[link.Target]
+		if !ok1 || !ok2 {
+			continue
+		}
+		s, d := t.Nodes[si], t.Nodes[ti]
+		x2, y2 := d.X, d.Y
+		if dist := math.Hypot(d.X-s.X, d.Y-s.Y); dist > 0 {
+			r := nodeRadius(d)
+			x2 -= (d.X - s.X) / dist * r
+			y2 -= (d.Y - s.Y) / dist * r
+		}
+		width := link.Width
+		if width == 0 {
+			width = 1
+		}
+		marker := ""
+		if link.Directed {
+			marker = fmt.Sprintf(` marker-end="url(#%s_arrow)"`, t.IdComponent)
+		}
+		id := html.EscapeString(template.JSEscapeString(t.linkID(link)))
+		fmt.Fprintf(sb, `<g style="cursor:pointer" onclick="send_event('%s','LinkClick','%s')"><line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#999" stroke-width="%d"%s/>`,
+			t.IdComponent, id, s.X, s.Y, x2, y2, width, marker)
+		if link.Label != "" {
+			fmt.Fprintf(sb, `<text x="%.1f" y="%.1f" font-size="10" text-anchor="middle" fill="#666">%s</text>`, (s.X+d.X)/2, (s.Y+d.Y)/2, html.EscapeString(link.Label))
+		}
+		sb.WriteString(`</g>`)
+	}
+	return sb.String()
+}
+
+// RenderNodes return the svg circles of nodes with labels
+func (t *NetworkGraph) RenderNodes() string {
+	sb := &strings.Builder{}
+	for _, n := range t.Nodes {
+		color := n.Color
+		if color == "" {
+			color = "#1976d2"
+		}
+		r := nodeRadius(n)
+		fmt.Fprintf(sb, `<g style="cursor:pointer" onclick="send_event('%s','NodeClick','%s')"><circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`,
+			t.IdComponent, html.EscapeString(template.JSEscapeString(n.ID)), n.X, n.Y, r, html.EscapeString(color))
+		fmt.Fprintf(sb, `<text x="%.1f" y="%.1f" font-size="12" text-anchor="middle">%s</text></g>`, n.X, n.Y+r+12, html.EscapeString(n.Label))
+	}
+	return sb.String()
+}
+
+// Layout place the nodes with a force-directed simulation (Verlet integration):
+// all nodes repel each other, links are springs and a weak gravity keeps the graph in the center
+func (t *NetworkGraph) Layout() {
+	n := len(t.Nodes)
+	if n == 0 {
+		return
+	}
+	width, height := float64(t.Width), float64(t.Height)
+	cx, cy := width/2, height/2
+	// ideal distance between nodes
+	k := math.Sqrt(width * height / float64(n))
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range t.Nodes {
+		if t.Nodes[i].X == 0 && t.Nodes[i].Y == 0 {
+			// initial position in a circle, it is deterministic
+			angle := 2 * math.Pi * float64(i) / float64(n)
+			x[i], y[i] = cx+math.Cos(angle)*k, cy+math.Sin(angle)*k
+		} else {
+			x[i], y[i] = t.Nodes[i].X, t.Nodes[i].Y
+		}
+	}
+	px := append([]float64{}, x...)
+	py := append([]float64{}, y...)
+	index := t.nodeIndex()
+	const (
+		damping = 0.9
+		dt      = 0.05
+		gravity = 0.01
+	)
+	for iter := 0; iter < t.Iterations; iter++ {
+		ax := make([]float64, n)
+		ay := make([]float64, n)
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				dx, dy := x[i]-x[j], y[i]-y[j]
+				d2 := dx*dx + dy*dy
+				if d2 < 0.01 {
+					dx, dy, d2 = 0.1, 0.1, 0.02
+				}
+				d := math.Sqrt(d2)
+				f := k * k / d
+				ax[i] += dx / d * f
+				ay[i] += dy / d * f
+				ax[j] -= dx / d * f
+				ay[j] -= dy / d * f
+			}
+		}
+		for _, link := range t.Links {
+			i, ok1 := index[link.Source]
+			j, ok2 := index[link.Target]
+			if !ok1 || !ok2 || i == j {
+				continue
+			}
+			dx, dy := x[j]-x[i], y[j]-y[i]
+			d := math.Max(math.Hypot(dx, dy), 0.1)
+			f := d * d / k
+			ax[i] += dx / d * f
+			ay[i] += dy / d * f
+			ax[j] -= dx / d * f
+			ay[j] -= dy / d * f
+		}
+		for i := 0; i < n; i++ {
+			ax[i] += (cx - x[i]) * gravity * k
+			ay[i] += (cy - y[i]) * gravity * k
+			nx := x[i] + (x[i]-px[i])*damping + ax[i]*dt*dt
+			ny := y[i] + (y[i]-py[i])*damping + ay[i]*dt*dt
+			px[i], py[i] = x[i], y[i]
+			x[i], y[i] = nx, ny
+		}
+	}
+	// fit the graph in the view
+	minX, minY, maxX, maxY := x[0], y[0], x[0], y[0]
+	for i := 1; i < n; i++ {
+		minX, maxX = math.Min(minX, x[i]), math.Max(maxX, x[i])
+		minY, maxY = math.Min(minY, y[i]), math.Max(maxY, y[i])
+	}
+	margin := 40.0
+	scale := math.Min((width-2*margin)/math.Max(maxX-minX, 1), (height-2*margin)/math.Max(maxY-minY, 1))
+	for i := range t.Nodes {
+		t.Nodes[i].X = margin + (x[i]-minX)*scale
+		t.Nodes[i].Y = margin + (y[i]-minY)*scale
+	}
+	t.laidOut = true
+}
+
+func (t *NetworkGraph) NodeClick(data interface{}) {
+	if t.OnNodeClick != nil {
+		t.OnNodeClick(fmt.Sprint(data))
+	}
+}
+
+func (t *NetworkGraph) LinkClick(data interface{}) {
+	if t.OnLinkClick != nil {
+		t.OnLinkClick(fmt.Sprint(data))
+	}
+}
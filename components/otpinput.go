@@ -0,0 +1,101 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+// OTPInput is a code entry with one input per char, the wasm moves the focus between the inputs and sends OTPChange
+// with the code on each keystroke and OTPComplete when all inputs are filled
+type OTPInput struct {
+	*liveview.ComponentDriver[*OTPInput]
+	// Length is the number of inputs (default 6)
+	Length int
+	// Type is "numeric" (default) or "alphanumeric"
+	Type  string
+	Value string
+	// Mask show the chars as password
+	Mask bool
+	// AutoSubmit submit the form of inputs when the code is complete
+	AutoSubmit bool
+	OnComplete func(otp string)
+	OnChange   func(otp string)
+}
+
+func (t *OTPInput) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *OTPInput) Start() {
+	if t.Length <= 0 {
+		t.Length = 6
+	}
+	if t.Type != "alphanumeric" {
+		t.Type = "numeric"
+	}
+	t.Commit()
+}
+
+func (t *OTPInput) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="otp-input" role="group" aria-label="One-time code" style="display:inline-flex;gap:8px">{{.RenderInputs}}</div>`
+}
+
+// RenderInputs return the inputs with the chars of Value
+func (t *OTPInput) RenderInputs() string {
+	chars := []rune(t.Value)
+	inputType, inputMode := "text", "text"
+	if t.Mask {
+		inputType = "password"
+	}
+	if t.Type == "numeric" {
+		inputMode = "numeric"
+	}
+	sb := &strings.Builder{}
+	for i := 0; i < t.Length; i++ {
+		value := ""
+		if i < len(chars) {
+			value = string(chars[i])
+		}
+		autocomplete := "off"
+		if i == 0 {
+			autocomplete = "one-time-code"
+		}
+		fmt.Fprintf(sb, `<input type="%s" inputmode="%s" maxlength="1" autocomplete="%s" id="%s_%d" value="%s" aria-label="Character %d" `+
+			`data-otp-component="%s" data-otp-type="%s"%s onfocus="this.select()" `+
+			`style="width:2.5em;height:2.5em;text-align:center;font-size:1.2em;border:1px solid #bbb;border-radius:4px"/>`,
+			inputType, inputMode, autocomplete, html.EscapeString(t.IdComponent), i, html.EscapeString(value), i+1,
+			html.EscapeString(t.IdComponent), t.Type, t.autoSubmitAttr())
+	}
+	return sb.String()
+}
+
+func (t *OTPInput) autoSubmitAttr() string {
+	if t.AutoSubmit {
+		return " data-otp-autosubmit"
+	}
+	return ""
+}
+
+func (t *OTPInput) OTPChange(data interface{}) {
+	t.Value = fmt.Sprint(data)
+	if t.OnChange != nil {
+		t.OnChange(t.Value)
+	}
+}
+
+func (t *OTPInput) OTPComplete(data interface{}) {
+	t.Value = fmt.Sprint(data)
+	if t.OnComplete != nil {
+		t.OnComplete(t.Value)
+	}
+}
+
+// Clear empty the inputs and focus the first
+func (t *OTPInput) Clear() {
+	t.Value = ""
+	t.Commit()
+	t.FocusElement(t.IdComponent+"_0", false)
+}
@@ -0,0 +1,351 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type PivotTable struct {
+	*liveview.ComponentDriver[*PivotTable]
+	Data       []map[string]interface{}
+	RowFields  []string
+	ColFields  []string
+	ValueField string
+	// Aggregator is "sum" (default), "count", "avg", "min" or "max"
+	Aggregator string
+	// SubTotals add the total column and row, and a subtotal row per value of first row field
+	SubTotals bool
+	OnChange  func(rows, cols []string, agg string)
+}
+
+// pivotCell accumulate the values of a cell for all aggregators
+type pivotCell struct {
+	sum, min, max float64
+	count         int
+}
+
+func (c *pivotCell) add(v float64) {
+	if c.count == 0 || v < c.min {
+		c.min = v
+	}
+	if c.count == 0 || v > c.max {
+		c.max = v
+	}
+	c.sum += v
+	c.count++
+}
+
+func (c *pivotCell) value(agg string) float64 {
+	if c == nil || c.count == 0 {
+		return math.NaN()
+	}
+	switch agg {
+	case "count":
+		return float64(c.count)
+	case "avg":
+		return c.sum / float64(c.count)
+	case "min":
+		return c.min
+	case "max":
+		return c.max
+	}
+	return c.sum
+}
+
+func (t *PivotTable) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *PivotTable) Start() {
+	if t.Aggregator == "" {
+		t.Aggregator = "sum"
+	}
+	t.Commit()
+}
+
+func (t *PivotTable) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="pivot-table">
+	<div style="display:flex;gap:8px;margin-bottom:8px;flex-wrap:wrap">
+		{{.RenderFieldList "available" "Fields"}}
+		{{.RenderFieldList "rows" "Rows"}}
+		{{.RenderFieldList "cols" "Columns"}}
+		<label>Aggregator
+			<select onchange="send_event('{{.IdComponent}}', 'SetAggregator', this.value)">
+				{{range .Aggregators}}<option value="{{.}}" {{if eq . $.Aggregator}}selected{{end}}>{{.}}</option>{{end}}
+			</select>
+		</label>
+	</div>
+	{{.RenderTable}}
+</div>`
+}
+
+func (t *PivotTable) Aggregators() []string {
+	return []string{"sum", "count", "avg", "min", "max"}
+}
+
+// AvailableFields return the fields of Data that are not in rows or columns
+func (t *PivotTable) AvailableFields() []string {
+	used := make(map[string]bool)
+	for _, f := range append(append([]string{}, t.RowFields...), t.ColFields...) {
+		used[f] = true
+	}
+	fields := make(map[string]bool)
+	for _, row := range t.Data {
+		for k := range row {
+			if !used[k] {
+				fields[k] = true
+			}
+		}
+	}
+	result := make([]string, 0, len(fields))
+	for k := range fields {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// RenderFieldList return a drop zone with the draggable fields of list ("available", "rows" or "cols")
+func (t *PivotTable) RenderFieldList(list string, title string) string {
+	fields := t.AvailableFields()
+	switch list {
+	case "rows":
+		fields = t.RowFields
+	case "cols":
+		fields = t.ColFields
+	}
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<div class="pivot-fields" style="min-width:120px;min-height:32px;border:1px dashed #aaa;padding:4px" ondragover="event.preventDefault()" `+
+		`ondrop="event.preventDefault(); send_event('%s', 'MoveField', JSON.stringify({field: event.dataTransfer.getData('text/plain'), to: '%s'}))">`,
+		t.IdComponent, list)
+	fmt.Fprintf(sb, `<div style="font-size:11px;color:gray">%s</div>`, html.EscapeString(title))
+	for _, f := range fields {
+		escaped := html.EscapeString(f)
+		fmt.Fprintf(sb, `<span draggable="true" ondragstart="event.dataTransfer.setData('text/plain', this.dataset.field)" data-field="%s" `+
+			`style="display:inline-block;margin:2px;padding:2px 6px;background:#e3f2fd;border-radius:3px;cursor:move">%s</span>`, escaped, escaped)
+	}
+	sb.WriteString(`</div>`)
+	return sb.String()
+}
+
+func pivotFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func pivotKey(row map[string]interface{}, fields []string) []string {
+	key := make([]string, len(fields))
+	for i, f := range fields {
+		if v, ok := row[f]; ok && v != nil {
+			key[i] = fmt.Sprint(v)
+		}
+	}
+	return key
+}
+
+// pivotMatrix is the result of Compute, the keys are the values of fields joined with "\x00"
+type pivotMatrix struct {
+	rows, cols []string
+	cells      map[string]map[string]*pivotCell
+	rowTotals  map[string]*pivotCell
+	colTotals  map[string]*pivotCell
+	groups     map[string]map[string]*pivotCell
+	groupTotal map[string]*pivotCell
+	total      *pivotCell
+}
+
+func (m *pivotMatrix) cell(cells map[string]map[string]*pivotCell, row, col string) *pivotCell {
+	if cells[row] == nil {
+		cells[row] = make(map[string]*pivotCell)
+	}
+	if cells[row][col] == nil {
+		cells[row][col] = &pivotCell{}
+	}
+	return cells[row][col]
+}
+
+func pivotTotal(totals map[string]*pivotCell, key string) *pivotCell {
+	if totals[key] == nil {
+		totals[key] = &pivotCell{}
+	}
+	return totals[key]
+}
+
+// compute aggregate ValueField for each combination of values of RowFields and ColFields
+func (t *PivotTable) compute() *pivotMatrix {
+	m := &pivotMatrix{
+		cells:      make(map[string]map[string]*pivotCell),
+		rowTotals:  make(map[string]*pivotCell),
+		colTotals:  make(map[string]*pivotCell),
+		groups:     make(map[string]map[string]*pivotCell),
+		groupTotal: make(map[string]*pivotCell),
+		total:      &pivotCell{},
+	}
+	rows, cols := make(map[string]bool), make(map[string]bool)
+	for _, record := range t.Data {
+		value := 1.0
+		if t.Aggregator != "count" {
+			v, ok := pivotFloat(record[t.ValueField])
+			if !ok {
+				continue
+			}
+			value = v
+		}
+		rowParts := pivotKey(record, t.RowFields)
+		row := strings.Join(rowParts, "\x00")
+		col := strings.Join(pivotKey(record, t.ColFields), "\x00")
+		rows[row], cols[col] = true, true
+		m.cell(m.cells, row, col).add(value)
+		pivotTotal(m.rowTotals, row).add(value)
+		pivotTotal(m.colTotals, col).add(value)
+		if len(rowParts) > 0 {
+			m.cell(m.groups, rowParts[0], col).add(value)
+			pivotTotal(m.groupTotal, rowParts[0]).add(value)
+		}
+		m.total.add(value)
+	}
+	for k := range rows {
+		m.rows = append(m.rows, k)
+	}
+	for k := range cols {
+		m.cols = append(m.cols, k)
+	}
+	sort.Strings(m.rows)
+	sort.Strings(m.cols)
+	return m
+}
+
+func (t *PivotTable) formatValue(c *pivotCell) string {
+	v := c.value(t.Aggregator)
+	if math.IsNaN(v) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// RenderTable return the html table of pivot
+func (t *PivotTable) RenderTable() string {
+	m := t.compute()
+	sb := &strings.Builder{}
+	sb.WriteString(`<table style="border-collapse:collapse" border="1" cellpadding="4"><thead><tr>`)
+	for _, f := range t.RowFields {
+		sb.WriteString(`<th>` + html.EscapeString(f) + `</th>`)
+	}
+	if len(t.RowFields) == 0 {
+		sb.WriteString(`<th></th>`)
+	}
+	for _, col := range m.cols {
+		label := strings.ReplaceAll(col, "\x00", " / ")
+		if len(t.ColFields) == 0 {
+			label = t.Aggregator
+		}
+		sb.WriteString(`<th>` + html.EscapeString(label) + `</th>`)
+	}
+	if t.SubTotals {
+		sb.WriteString(`<th>Total</th>`)
+	}
+	sb.WriteString(`</tr></thead><tbody>`)
+	subtotal := func(label string, cells map[string]*pivotCell, total *pivotCell) {
+		span := len(t.RowFields)
+		if span == 0 {
+			span = 1
+		}
+		fmt.Fprintf(sb, `<tr style="font-weight:bold;background:#f5f5f5"><td colspan="%d">%s</td>`, span, html.EscapeString(label))
+		for _, col := range m.cols {
+			sb.WriteString(`<td style="text-align:right">` + t.formatValue(cells[col]) + `</td>`)
+		}
+		sb.WriteString(`<td style="text-align:right">` + t.formatValue(total) + `</td></tr>`)
+	}
+	for i, row := range m.rows {
+		parts := strings.Split(row, "\x00")
+		sb.WriteString(`<tr>`)
+		if len(t.RowFields) == 0 {
+			sb.WriteString(`<td></td>`)
+		}
+		for j := range t.RowFields {
+			sb.WriteString(`<th style="text-align:left">` + html.EscapeString(parts[j]) + `</th>`)
+		}
+		for _, col := range m.cols {
+			sb.WriteString(`<td style="text-align:right">` + t.formatValue(m.cells[row][col]) + `</td>`)
+		}
+		if t.SubTotals {
+			sb.WriteString(`<td style="text-align:right;font-weight:bold">` + t.formatValue(m.rowTotals[row]) + `</td>`)
+		}
+		sb.WriteString(`</tr>`)
+		// the subtotal of group is after its last row
+		if t.SubTotals && len(t.RowFields) > 1 && (i == len(m.rows)-1 || strings.Split(m.rows[i+1], "\x00")[0] != parts[0]) {
+			subtotal(parts[0]+" total", m.groups[parts[0]], m.groupTotal[parts[0]])
+		}
+	}
+	if t.SubTotals {
+		subtotal("Total", m.colTotals, m.total)
+	}
+	sb.WriteString(`</tbody></table>`)
+	return sb.String()
+}
+
+func (t *PivotTable) changed() {
+	if t.OnChange != nil {
+		t.OnChange(t.RowFields, t.ColFields, t.Aggregator)
+	}
+	t.Commit()
+}
+
+func removeField(fields []string, field string) []string {
+	result := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f != field {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// MoveField is sent by the drop of a field in a list, data is {field, to}
+func (t *PivotTable) MoveField(data interface{}) {
+	var move struct {
+		Field string `json:"field"`
+		To    string `json:"to"`
+	}
+	if err := json.Unmarshal([]byte(fmt.Sprint(data)), &move); err != nil || move.Field == "" {
+		return
+	}
+	t.RowFields = removeField(t.RowFields, move.Field)
+	t.ColFields = removeField(t.ColFields, move.Field)
+	switch move.To {
+	case "rows":
+		t.RowFields = append(t.RowFields, move.Field)
+	case "cols":
+		t.ColFields = append(t.ColFields, move.Field)
+	}
+	t.changed()
+}
+
+func (t *PivotTable) SetAggregator(data interface{}) {
+	t.Aggregator = fmt.Sprint(data)
+	t.changed()
+}
@@ -0,0 +1,95 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type QRCode struct {
+	*liveview.ComponentDriver[*QRCode]
+	Content string
+	// Size in px (default 256)
+	Size int
+	// ErrorCorrection is "L", "M", "Q" or "H" (default "M", or "H" with LogoURL)
+	ErrorCorrection string
+	ForegroundColor string
+	BackgroundColor string
+	// LogoURL is shown in the center of code
+	LogoURL string
+	Error   string
+}
+
+func (t *QRCode) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *QRCode) Start() {
+	if t.Size == 0 {
+		t.Size = 256
+	}
+	if t.ErrorCorrection == "" {
+		t.ErrorCorrection = "M"
+		if t.LogoURL != "" {
+			// the logo hides modules, H recovers 30%
+			t.ErrorCorrection = "H"
+		}
+	}
+	if t.ForegroundColor == "" {
+		t.ForegroundColor = "#000"
+	}
+	if t.BackgroundColor == "" {
+		t.BackgroundColor = "#fff"
+	}
+	t.Commit()
+}
+
+func (t *QRCode) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="qrcode">{{.RenderSVG}}</div>`
+}
+
+// RenderSVG return the svg of code, the dark modules of each row are merged in rects
+func (t *QRCode) RenderSVG() string {
+	modules, err := qrEncode(t.Content, t.ErrorCorrection)
+	if err != nil {
+		t.Error = err.Error()
+		return `<div class="qrcode-error" style="color:red">` + html.EscapeString(t.Error) + `</div>`
+	}
+	t.Error = ""
+	const quiet = 4
+	n := len(modules) + 2*quiet
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<svg width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges" role="img" aria-label="%s">`,
+		t.Size, t.Size, n, n, html.EscapeString(t.Content))
+	fmt.Fprintf(sb, `<rect width="%d" height="%d" fill="%s"/><g fill="%s">`, n, n, html.EscapeString(t.BackgroundColor), html.EscapeString(t.ForegroundColor))
+	for y, row := range modules {
+		for x := 0; x < len(row); x++ {
+			if !row[x] {
+				continue
+			}
+			start := x
+			for x+1 < len(row) && row[x+1] {
+				x++
+			}
+			fmt.Fprintf(sb, `<rect x="%d" y="%d" width="%d" height="1"/>`, start+quiet, y+quiet, x-start+1)
+		}
+	}
+	sb.WriteString(`</g>`)
+	if t.LogoURL != "" {
+		// the logo uses 1/5 of the code
+		logo := float64(len(modules)) / 5
+		pos := (float64(n) - logo) / 2
+		fmt.Fprintf(sb, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="#fff"/>`, pos-0.5, pos-0.5, logo+1, logo+1)
+		fmt.Fprintf(sb, `<image href="%s" x="%.2f" y="%.2f" width="%.2f" height="%.2f" preserveAspectRatio="xMidYMid meet"/>`, html.EscapeString(t.LogoURL), pos, pos, logo, logo)
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// SetContent change the content and Commit
+func (t *QRCode) SetContent(content string) {
+	t.Content = content
+	t.Commit()
+}
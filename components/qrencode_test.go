@@ -0,0 +1,353 @@
+package components
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestQRReedSolomon(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		ecc  []byte
+	}{
+		// "HELLO WORLD" 1-M in alphanumeric mode
+		{"hello world 1-M",
+			[]byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17},
+			[]byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}},
+		// "01234567" 1-M in numeric mode, ISO/IEC 18004 annex I
+		{"01234567 1-M",
+			[]byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11},
+			[]byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55}},
+	}
+	for _, tt := range tests {
+		if got := qrRSRemainder(tt.data, qrRSDivisor(len(tt.ecc))); !bytes.Equal(got, tt.ecc) {
+			t.Errorf("%s: ecc = %v, want %v", tt.name, got, tt.ecc)
+		}
+	}
+}
+
+func TestQRCapacity(t *testing.T) {
+	tests := []struct {
+		version int
+		level   string
+		data    int
+	}{
+		{1, "L", 19}, {1, "M", 16}, {1, "Q", 13}, {1, "H", 9},
+		{5, "Q", 62}, {10, "M", 216}, {40, "L", 2956}, {40, "H", 1276},
+	}
+	for _, tt := range tests {
+		if got := qrDataCodewords(tt.version, tt.level); got != tt.data {
+			t.Errorf("qrDataCodewords(%d, %s) = %d, want %d", tt.version, tt.level, got, tt.data)
+		}
+	}
+	// the bytes that fit in version 1
+	for level, max := range map[string]int{"L": 17, "M": 14, "Q": 11, "H": 7} {
+		if modules, _ := qrEncode(strings.Repeat("a", max), level); len(modules) != 21 {
+			t.Errorf("%d bytes %s: size %d, want 21", max, level, len(modules))
+		}
+		if modules, _ := qrEncode(strings.Repeat("a", max+1), level); len(modules) != 25 {
+			t.Errorf("%d bytes %s: size %d, want 25", max+1, level, len(modules))
+		}
+	}
+	if _, err := qrEncode(strings.Repeat("a", 2953), "L"); err != nil {
+		t.Errorf("2953 bytes L: %v", err)
+	}
+	if _, err := qrEncode(strings.Repeat("a", 2954), "L"); err != errQRTooLong {
+		t.Errorf("2954 bytes L: err = %v, want %v", err, errQRTooLong)
+	}
+}
+
+func TestQRAlignmentPositions(t *testing.T) {
+	tests := map[int][]int{
+		2:  {6, 18},
+		7:  {6, 22, 38},
+		14: {6, 26, 46, 66},
+		32: {6, 34, 60, 86, 112, 138},
+		36: {6, 24, 50, 76, 102, 128, 154},
+		40: {6, 30, 58, 86, 114, 142, 170},
+	}
+	if got := qrAlignmentPositions(1); len(got) != 0 {
+		t.Errorf("qrAlignmentPositions(1) = %v, want none", got)
+	}
+	for version, want := range tests {
+		if got := qrAlignmentPositions(version); !reflect.DeepEqual(got, want) {
+			t.Errorf("qrAlignmentPositions(%d) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+// qrFormatTable is the format information of ISO/IEC 18004 table C.1 by level and mask, bit 14 first
+var qrFormatTable = map[string][8]string{
+	"L": {"111011111000100", "111001011110011", "111110110101010", "111100010011101", "110011000101111", "110001100011000", "110110001000001", "110100101110110"},
+	"M": {"101010000010010", "101000100100101", "101111001111100", "101101101001011", "100010111111001", "100000011001110", "100111110010111", "100101010100000"},
+	"Q": {"011010101011111", "011000001101000", "011111100110001", "011101000000110", "010010010110100", "010000110000011", "010111011011010", "010101111101101"},
+	"H": {"001011010001001", "001001110111110", "001110011100111", "001100111010000", "000011101100010", "000001001010101", "000110100001100", "000100000111011"},
+}
+
+func TestQRFormatBits(t *testing.T) {
+	for level, masks := range qrFormatTable {
+		for mask, want := range masks {
+			m := newQRMatrix(1)
+			m.drawFormatBits(level, mask)
+			first, second := qrReadFormat(m.modules)
+			if first != want || second != want {
+				t.Errorf("format %s mask %d = %s / %s, want %s", level, mask, first, second, want)
+			}
+		}
+	}
+}
+
+func TestQRVersionBits(t *testing.T) {
+	// version information of ISO/IEC 18004 table D.1, bit 17 first
+	tests := map[int]string{
+		7:  "000111110010010100",
+		8:  "001000010110111100",
+		21: "010101011010000011",
+		40: "101000110001101001",
+	}
+	for version, want := range tests {
+		m := newQRMatrix(version)
+		m.drawFunctionPatterns(version, "M")
+		var bottomLeft, topRight []byte
+		for i := 17; i >= 0; i-- {
+			bottomLeft = append(bottomLeft, qrBit(m.modules[m.size-11+i%3][i/3]))
+			topRight = append(topRight, qrBit(m.modules[i/3][m.size-11+i%3]))
+		}
+		if string(bottomLeft) != want || string(topRight) != want {
+			t.Errorf("version %d = %s / %s, want %s", version, bottomLeft, topRight, want)
+		}
+	}
+}
+
+// TestQREncodeDecode read the symbols with the reader of this file, it is written from the standard and does not use
+// the encoder: function patterns, format, mask, zigzag, blocks with their ecc and the byte segment
+func TestQREncodeDecode(t *testing.T) {
+	tests := []struct {
+		content string
+		level   string
+		version int
+	}{
+		{"HELLO WORLD", "M", 1},
+		{"https://example.com", "L", 2},
+		{"otpauth://totp/user@example.com?secret=JBSWY3DPEHPK3PXP", "Q", 5},
+		{"WIFI:T:WPA;S:my network;P:pässwörd;;", "H", 5},
+		{strings.Repeat("liveview ", 20), "H", 14},
+		{strings.Repeat("0123456789", 30), "M", 13},
+		{strings.Repeat("x", 1000), "L", 22},
+	}
+	for _, tt := range tests {
+		modules, err := qrEncode(tt.content, tt.level)
+		if err != nil {
+			t.Fatalf("%q %s: %v", tt.content, tt.level, err)
+		}
+		if version := (len(modules) - 17) / 4; version != tt.version {
+			t.Errorf("%q %s: version %d, want %d", tt.content, tt.level, version, tt.version)
+			continue
+		}
+		if msg := qrCheckFunctionPatterns(modules); msg != "" {
+			t.Errorf("%q %s: %s", tt.content, tt.level, msg)
+			continue
+		}
+		content, msg := qrDecode(modules, tt.version, tt.level)
+		if msg != "" {
+			t.Errorf("%q %s: %s", tt.content, tt.level, msg)
+			continue
+		}
+		if content != tt.content {
+			t.Errorf("decode = %q, want %q", content, tt.content)
+		}
+	}
+}
+
+func qrBit(dark bool) byte {
+	if dark {
+		return '1'
+	}
+	return '0'
+}
+
+// qrReadFormat return both copies of the format information, bit 14 first
+func qrReadFormat(modules [][]bool) (string, string) {
+	size := len(modules)
+	first, second := make([]byte, 15), make([]byte, 15)
+	for i := 0; i < 15; i++ {
+		var a, b bool
+		switch {
+		case i <= 5:
+			a = modules[i][8]
+		case i == 6:
+			a = modules[7][8]
+		case i == 7:
+			a = modules[8][8]
+		case i == 8:
+			a = modules[8][7]
+		default:
+			a = modules[8][14-i]
+		}
+		if i < 8 {
+			b = modules[8][size-1-i]
+		} else {
+			b = modules[size-15+i][8]
+		}
+		first[14-i], second[14-i] = qrBit(a), qrBit(b)
+	}
+	return string(first), string(second)
+}
+
+// qrCheckFunctionPatterns return a message if the finders, separators, timing patterns or the dark module are wrong
+func qrCheckFunctionPatterns(modules [][]bool) string {
+	size := len(modules)
+	for _, corner := range [][2]int{{0, 0}, {size - 7, 0}, {0, size - 7}} {
+		for dy := -1; dy <= 7; dy++ {
+			for dx := -1; dx <= 7; dx++ {
+				x, y := corner[0]+dx, corner[1]+dy
+				if x < 0 || y < 0 || x >= size || y >= size {
+					continue
+				}
+				ring := qrMax(qrAbs(dx-3), qrAbs(dy-3))
+				if want := ring != 2 && ring != 4; modules[y][x] != want {
+					return "wrong finder or separator"
+				}
+			}
+		}
+	}
+	for i := 8; i < size-8; i++ {
+		if modules[6][i] != (i%2 == 0) || modules[i][6] != (i%2 == 0) {
+			return "wrong timing pattern"
+		}
+	}
+	if !modules[size-8][8] {
+		return "no dark module"
+	}
+	return ""
+}
+
+// qrIsFunction report if the module is not for data
+func qrIsFunction(x, y, version int) bool {
+	size := version*4 + 17
+	if (x <= 8 && y <= 8) || (x >= size-8 && y <= 8) || (x <= 8 && y >= size-8) || x == 6 || y == 6 {
+		return true
+	}
+	if version >= 7 && ((x >= size-11 && y < 6) || (y >= size-11 && x < 6)) {
+		return true
+	}
+	positions := qrAlignmentPositions(version)
+	last := len(positions) - 1
+	for i, ax := range positions {
+		for j, ay := range positions {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			if qrAbs(x-ax) <= 2 && qrAbs(y-ay) <= 2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// qrMaskTable is the mask condition of ISO/IEC 18004 table 10, i is the row and j the column
+var qrMaskTable = [8]func(i, j int) bool{
+	func(i, j int) bool { return (i+j)%2 == 0 },
+	func(i, j int) bool { return i%2 == 0 },
+	func(i, j int) bool { return j%3 == 0 },
+	func(i, j int) bool { return (i+j)%3 == 0 },
+	func(i, j int) bool { return (i/2+j/3)%2 == 0 },
+	func(i, j int) bool { return (i*j)%2+(i*j)%3 == 0 },
+	func(i, j int) bool { return ((i*j)%2+(i*j)%3)%2 == 0 },
+	func(i, j int) bool { return ((i*j)%3+(i+j)%2)%2 == 0 },
+}
+
+// qrDecode return the content of the byte segment of modules or a message with the error
+func qrDecode(modules [][]bool, version int, level string) (string, string) {
+	size := len(modules)
+	format, _ := qrReadFormat(modules)
+	mask := -1
+	for i, f := range qrFormatTable[level] {
+		if f == format {
+			mask = i
+		}
+	}
+	if mask < 0 {
+		return "", "format " + format + " is not of level " + level
+	}
+
+	// the bits of data modules in zigzag, upwards from the bottom right corner
+	var bits []bool
+	upward := true
+	for right := size - 1; right > 0; right -= 2 {
+		if right == 6 {
+			right--
+		}
+		for k := 0; k < size; k++ {
+			y := k
+			if upward {
+				y = size - 1 - k
+			}
+			for x := right; x >= right-1; x-- {
+				if !qrIsFunction(x, y, version) {
+					bits = append(bits, modules[y][x] != qrMaskTable[mask](y, x))
+				}
+			}
+		}
+		upward = !upward
+	}
+	raw := make([]byte, len(bits)/8)
+	for i := range raw {
+		for _, bit := range bits[i*8 : i*8+8] {
+			raw[i] <<= 1
+			if bit {
+				raw[i] |= 1
+			}
+		}
+	}
+
+	// blocks: the data codewords are interleaved first and then the ecc codewords
+	numBlocks := qrNumBlocks[level][version-1]
+	eccLen := qrEccCodewordsPerBlock[level][version-1]
+	dataLen := len(raw) - numBlocks*eccLen
+	numLong := dataLen % numBlocks
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < dataLen/numBlocks+1; i++ {
+		for j := range blocks {
+			if i < dataLen/numBlocks || j >= numBlocks-numLong {
+				blocks[j] = append(blocks[j], raw[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for j, block := range blocks {
+		ecc := make([]byte, eccLen)
+		for i := range ecc {
+			ecc[i] = raw[dataLen+i*numBlocks+j]
+		}
+		if got := qrRSRemainder(block, qrRSDivisor(eccLen)); !bytes.Equal(got, ecc) {
+			return "", "wrong ecc"
+		}
+		data = append(data, block...)
+	}
+
+	// byte segment: mode 0100, count and bytes
+	if data[0]>>4 != 4 {
+		return "", "mode is not byte"
+	}
+	var count, start int
+	if version < 10 {
+		count, start = int(data[0]&0xF)<<4|int(data[1]>>4), 1
+	} else {
+		count, start = int(data[0]&0xF)<<12|int(data[1])<<4|int(data[2]>>4), 2
+	}
+	if start+count+1 > len(data) {
+		return "", "count out of range"
+	}
+	content := make([]byte, count)
+	for i := range content {
+		content[i] = data[start+i]<<4 | data[start+i+1]>>4
+	}
+	return string(content), ""
+}
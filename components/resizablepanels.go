@@ -0,0 +1,152 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+// ResizablePanels show two panels with a draggable divider, the ratio is saved in the localStorage of browser by
+// component id, so it survives a page reload of the same user and it is not shared with other sessions
+type ResizablePanels struct {
+	*liveview.ComponentDriver[*ResizablePanels]
+	LeftContent  string
+	RightContent string
+	// SplitRatio is the size of left (or top) panel between 0 and 1 (default 0.5)
+	SplitRatio    float64
+	MinLeftWidth  int
+	MinRightWidth int
+	// Direction is "horizontal" (default) or "vertical"
+	Direction string
+	Height    string
+	// Collapsible collapse the smaller panel when the divider is double-clicked
+	Collapsible   bool
+	OnResize      func(ratio float64)
+	restoreRatio  float64
+	collapsedSide string
+}
+
+func (t *ResizablePanels) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *ResizablePanels) Start() {
+	if t.SplitRatio <= 0 || t.SplitRatio > 1 {
+		t.SplitRatio = 0.5
+	}
+	if t.Direction == "" {
+		t.Direction = "horizontal"
+	}
+	if t.Height == "" {
+		t.Height = "400px"
+	}
+	t.Commit()
+	t.EvalScript(fmt.Sprintf(`var r = localStorage.getItem(%s); if (r !== null) { send_event(%s, 'RestoreRatio', r) }`,
+		jsJSON(t.storageKey()), jsJSON(t.IdComponent)))
+}
+
+func (t *ResizablePanels) storageKey() string {
+	return "liveview_split_" + t.IdComponent
+}
+
+// saveRatio save SplitRatio in the localStorage of browser
+func (t *ResizablePanels) saveRatio() {
+	t.EvalScript(fmt.Sprintf(`localStorage.setItem(%s, %s)`, jsJSON(t.storageKey()), jsJSON(strconv.FormatFloat(t.SplitRatio, 'f', -1, 64))))
+}
+
+func (t *ResizablePanels) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="resizable-panels" data-split-component="{{.IdComponent}}" data-split-direction="{{.Direction}}"
+	data-split-min-first="{{.MinLeftWidth}}" data-split-min-second="{{.MinRightWidth}}"
+	style="display:flex;flex-direction:{{if eq .Direction "vertical"}}column{{else}}row{{end}};height:{{.Height}}">
+	<div class="panel" style="flex-basis:{{.FirstBasis}};overflow:auto;min-width:0;min-height:0">{{.LeftContent}}</div>
+	<div class="divider" {{if .Collapsible}}ondblclick="send_event('{{.IdComponent}}', 'Collapse')"{{end}}
+		style="flex:none;background:#ddd;{{if eq .Direction "vertical"}}height:6px;cursor:row-resize{{else}}width:6px;cursor:col-resize{{end}}"></div>
+	<div class="panel" style="flex-basis:{{.SecondBasis}};overflow:auto;min-width:0;min-height:0">{{.RightContent}}</div>
+</div>`
+}
+
+func (t *ResizablePanels) FirstBasis() string {
+	return fmt.Sprintf("%g%%", t.SplitRatio*100)
+}
+
+func (t *ResizablePanels) SecondBasis() string {
+	return fmt.Sprintf("%g%%", (1-t.SplitRatio)*100)
+}
+
+// SetRatio change the ratio (clipped to [0, 1]), save it and Commit
+func (t *ResizablePanels) SetRatio(ratio float64) {
+	t.setRatio(ratio)
+	t.saveRatio()
+	t.Commit()
+}
+
+// RestoreRatio is sent with the ratio saved in the browser when the component starts
+func (t *ResizablePanels) RestoreRatio(data interface{}) {
+	ratio, ok := parseRatio(data)
+	if !ok || ratio < 0 || ratio > 1 || ratio == t.SplitRatio {
+		return
+	}
+	t.setRatio(ratio)
+	t.Commit()
+}
+
+func (t *ResizablePanels) setRatio(ratio float64) {
+	if ratio < 0 {
+		ratio = 0
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+	t.SplitRatio = ratio
+}
+
+func parseRatio(data interface{}) (float64, bool) {
+	ratio, err := strconv.ParseFloat(fmt.Sprint(data), 64)
+	return ratio, err == nil
+}
+
+// Resize is sent by the wasm while dragging, the panels are already resized in the browser
+func (t *ResizablePanels) Resize(data interface{}) {
+	if ratio, ok := parseRatio(data); ok {
+		t.setRatio(ratio)
+	}
+}
+
+// ResizeEnd is sent by the wasm when the drag stops
+func (t *ResizablePanels) ResizeEnd(data interface{}) {
+	ratio, ok := parseRatio(data)
+	if !ok {
+		return
+	}
+	t.collapsedSide = ""
+	t.setRatio(ratio)
+	t.saveRatio()
+	if t.OnResize != nil {
+		t.OnResize(t.SplitRatio)
+	}
+	t.Commit()
+}
+
+// Collapse collapse the smaller panel or restore the ratio if a panel is collapsed
+func (t *ResizablePanels) Collapse(data interface{}) {
+	if !t.Collapsible {
+		return
+	}
+	switch {
+	case t.collapsedSide != "":
+		t.collapsedSide = ""
+		t.setRatio(t.restoreRatio)
+	case t.SplitRatio <= 0.5:
+		t.collapsedSide, t.restoreRatio = "left", t.SplitRatio
+		t.setRatio(0)
+	default:
+		t.collapsedSide, t.restoreRatio = "right", t.SplitRatio
+		t.setRatio(1)
+	}
+	t.saveRatio()
+	if t.OnResize != nil {
+		t.OnResize(t.SplitRatio)
+	}
+	t.Commit()
+}
@@ -0,0 +1,213 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+// Slider is a range input, the value is sent with SliderChange while it is dragged (the wasm debounces it 50ms) and
+// the component is not rendered again so the drag is not interrupted
+type Slider struct {
+	*liveview.ComponentDriver[*Slider]
+	Min       float64
+	Max       float64
+	Step      float64
+	Value     float64
+	Label     string
+	ShowValue bool
+	// ShowTicks add a tick mark for each Step (max 100 ticks)
+	ShowTicks bool
+	Color     string
+	Disabled  bool
+	OnChange  func(value float64)
+}
+
+func (t *Slider) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func sliderDefaults(min, max, step *float64, color *string) {
+	if *min == 0 && *max == 0 {
+		*max = 100
+	}
+	if *step <= 0 {
+		*step = 1
+	}
+	if *color == "" {
+		*color = "#1976d2"
+	}
+}
+
+func (t *Slider) Start() {
+	sliderDefaults(&t.Min, &t.Max, &t.Step, &t.Color)
+	t.Value = sliderClamp(t.Value, t.Min, t.Max)
+	t.Commit()
+}
+
+// the input is the element with IdComponent, so SetValue of driver changes its value
+func (t *Slider) GetTemplate() string {
+	return `<div class="slider" style="display:flex;flex-direction:column;gap:4px">
+	{{if .Label}}<label for="{{.IdComponent}}">{{html .Label}}</label>{{end}}
+	<div style="display:flex;align-items:center;gap:8px">
+		<input type="range" id="{{.IdComponent}}" data-slider-component="{{.IdComponent}}" data-slider-event="SliderChange"
+			min="{{.FormatNumber .Min}}" max="{{.FormatNumber .Max}}" step="{{.FormatNumber .Step}}" value="{{.FormatNumber .Value}}"
+			style="flex:1;accent-color:{{html .Color}}" {{if .ShowTicks}}list="{{.IdComponent}}_ticks"{{end}} {{if .Disabled}}disabled{{end}}/>
+		{{if .ShowValue}}<output id="{{.IdComponent}}_value" data-slider-output="{{.IdComponent}}" style="min-width:3em;text-align:right">{{.FormatNumber .Value}}</output>{{end}}
+	</div>
+	{{if .ShowTicks}}{{.RenderTicks}}{{end}}
+</div>`
+}
+
+func (t *Slider) FormatNumber(v float64) string {
+	return sliderNumber(v)
+}
+
+func (t *Slider) RenderTicks() string {
+	return sliderTicks(t.IdComponent+"_ticks", t.Min, t.Max, t.Step)
+}
+
+func sliderNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func sliderClamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
+
+// sliderTicks return the datalist of tick marks, the step is multiplied when there are more than 100 ticks
+func sliderTicks(id string, min, max, step float64) string {
+	if step <= 0 || max <= min {
+		return ""
+	}
+	for (max-min)/step > 100 {
+		step *= 10
+	}
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<datalist id="%s">`, html.EscapeString(id))
+	for v := min; v <= max+step/1e6; v += step {
+		fmt.Fprintf(sb, `<option value="%s"></option>`, sliderNumber(v))
+	}
+	sb.WriteString(`</datalist>`)
+	return sb.String()
+}
+
+func (t *Slider) SliderChange(data interface{}) {
+	v, err := strconv.ParseFloat(fmt.Sprint(data), 64)
+	if err != nil {
+		return
+	}
+	t.Value = sliderClamp(v, t.Min, t.Max)
+	if t.OnChange != nil {
+		t.OnChange(t.Value)
+	}
+}
+
+// SetSliderValue clip v to [Min, Max], update the input in browser and call OnChange (SetValue is the method of
+// driver for set the value of element)
+func (t *Slider) SetSliderValue(v float64) {
+	t.Value = sliderClamp(v, t.Min, t.Max)
+	t.SetValue(sliderNumber(t.Value))
+	if t.ShowValue {
+		t.FillValueById(t.IdComponent+"_value", sliderNumber(t.Value))
+	}
+	if t.OnChange != nil {
+		t.OnChange(t.Value)
+	}
+}
+
+// DualSlider select a range with two range inputs overlaid in the same track, the thumbs do not cross
+type DualSlider struct {
+	*liveview.ComponentDriver[*DualSlider]
+	Min       float64
+	Max       float64
+	Step      float64
+	LowValue  float64
+	HighValue float64
+	Label     string
+	ShowValue bool
+	ShowTicks bool
+	Color     string
+	Disabled  bool
+	OnChange  func(low, high float64)
+}
+
+func (t *DualSlider) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *DualSlider) Start() {
+	sliderDefaults(&t.Min, &t.Max, &t.Step, &t.Color)
+	if t.LowValue == 0 && t.HighValue == 0 {
+		t.LowValue, t.HighValue = t.Min, t.Max
+	}
+	t.LowValue, t.HighValue = t.clamp(t.LowValue, t.HighValue)
+	t.Commit()
+}
+
+// the inputs have pointer-events only in the thumbs, so both thumbs can be dragged in the same track
+func (t *DualSlider) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="dual-slider" style="display:flex;flex-direction:column;gap:4px">
+	<style>
+		.dual-slider-track { position:relative; flex:1; height:24px; }
+		.dual-slider-track input { position:absolute; left:0; top:0; width:100%; margin:0; height:24px; background:none; pointer-events:none; }
+		.dual-slider-track input::-webkit-slider-thumb { pointer-events:auto; }
+		.dual-slider-track input::-moz-range-thumb { pointer-events:auto; }
+	</style>
+	{{if .Label}}<label>{{html .Label}}</label>{{end}}
+	<div style="display:flex;align-items:center;gap:8px">
+		<div class="dual-slider-track">
+			<input type="range" id="{{.IdComponent}}_low" data-slider-component="{{.IdComponent}}" data-slider-event="DualSliderChange" data-slider-role="low"
+				min="{{.FormatNumber .Min}}" max="{{.FormatNumber .Max}}" step="{{.FormatNumber .Step}}" value="{{.FormatNumber .LowValue}}"
+				style="accent-color:{{html .Color}}" {{if .ShowTicks}}list="{{.IdComponent}}_ticks"{{end}} {{if .Disabled}}disabled{{end}} aria-label="minimum"/>
+			<input type="range" id="{{.IdComponent}}_high" data-slider-component="{{.IdComponent}}" data-slider-event="DualSliderChange" data-slider-role="high"
+				min="{{.FormatNumber .Min}}" max="{{.FormatNumber .Max}}" step="{{.FormatNumber .Step}}" value="{{.FormatNumber .HighValue}}"
+				style="accent-color:{{html .Color}}" {{if .ShowTicks}}list="{{.IdComponent}}_ticks"{{end}} {{if .Disabled}}disabled{{end}} aria-label="maximum"/>
+		</div>
+		{{if .ShowValue}}<output id="{{.IdComponent}}_value" data-slider-output="{{.IdComponent}}" style="min-width:6em;text-align:right">{{.FormatNumber .LowValue}} – {{.FormatNumber .HighValue}}</output>{{end}}
+	</div>
+	{{if .ShowTicks}}{{.RenderTicks}}{{end}}
+</div>`
+}
+
+func (t *DualSlider) FormatNumber(v float64) string {
+	return sliderNumber(v)
+}
+
+func (t *DualSlider) RenderTicks() string {
+	return sliderTicks(t.IdComponent+"_ticks", t.Min, t.Max, t.Step)
+}
+
+func (t *DualSlider) clamp(low, high float64) (float64, float64) {
+	low, high = sliderClamp(low, t.Min, t.Max), sliderClamp(high, t.Min, t.Max)
+	if low > high {
+		low, high = high, low
+	}
+	return low, high
+}
+
+// DualSliderChange is sent while a thumb is dragged, data is [low, high]
+func (t *DualSlider) DualSliderChange(data interface{}) {
+	var values []float64
+	if err := json.Unmarshal([]byte(fmt.Sprint(data)), &values); err != nil || len(values) != 2 {
+		return
+	}
+	t.LowValue, t.HighValue = t.clamp(values[0], values[1])
+	if t.OnChange != nil {
+		t.OnChange(t.LowValue, t.HighValue)
+	}
+}
+
+// SetRange clip low and high to [Min, Max], Commit and call OnChange
+func (t *DualSlider) SetRange(low, high float64) {
+	t.LowValue, t.HighValue = t.clamp(low, high)
+	t.Commit()
+	if t.OnChange != nil {
+		t.OnChange(t.LowValue, t.HighValue)
+	}
+}
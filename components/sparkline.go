@@ -0,0 +1,158 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type Sparkline struct {
+	*liveview.ComponentDriver[*Sparkline]
+	Values []float64
+	Width  int
+	Height int
+	Color  string
+	// Type is "line" (default), "bar" or "area"
+	Type    string
+	ShowDot bool
+	// Min and Max are the limits of y axis, nil is the min/max of Values
+	Min *float64
+	Max *float64
+	// Tooltip show the value of each point with svg title
+	Tooltip bool
+}
+
+func (t *Sparkline) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Sparkline) Start() {
+	if t.Width == 0 {
+		t.Width = 80
+	}
+	if t.Height == 0 {
+		t.Height = 30
+	}
+	if t.Color == "" {
+		t.Color = "#1976d2"
+	}
+	if t.Type == "" {
+		t.Type = "line"
+	}
+	t.Commit()
+}
+
+func (t *Sparkline) GetTemplate() string {
+	return `<span id="{{.IdComponent}}" class="sparkline" style="display:inline-block;vertical-align:middle">{{.RenderSVG}}</span>`
+}
+
+// limits return the min and max of y axis
+func (t *Sparkline) limits() (float64, float64) {
+	min, max := 0.0, 0.0
+	for i, v := range t.Values {
+		if i == 0 || v < min {
+			min = v
+		}
+		if i == 0 || v > max {
+			max = v
+		}
+	}
+	if t.Min != nil {
+		min = *t.Min
+	}
+	if t.Max != nil {
+		max = *t.Max
+	}
+	if t.Type == "bar" && t.Min == nil && min > 0 {
+		// the bars start at 0
+		min = 0
+	}
+	if max == min {
+		max = min + 1
+	}
+	return min, max
+}
+
+func sparkNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// RenderSVG return the inline svg of Values, the points are computed in each render so the length of Values can change
+func (t *Sparkline) RenderSVG() string {
+	width, height := float64(t.Width), float64(t.Height)
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<svg width="%d" height="%d" viewBox="0 0 %d %d" preserveAspectRatio="none">`, t.Width, t.Height, t.Width, t.Height)
+	if len(t.Values) == 0 {
+		sb.WriteString(`</svg>`)
+		return sb.String()
+	}
+	color := html.EscapeString(t.Color)
+	min, max := t.limits()
+	// pad keep the dot and the stroke inside the svg
+	pad := 2.0
+	y := func(v float64) float64 {
+		if v < min {
+			v = min
+		}
+		if v > max {
+			v = max
+		}
+		return pad + (height-2*pad)*(1-(v-min)/(max-min))
+	}
+	title := func(i int, v float64) string {
+		if !t.Tooltip {
+			return ""
+		}
+		return fmt.Sprintf(`<title>%d: %s</title>`, i+1, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	if t.Type == "bar" {
+		barWidth := width / float64(len(t.Values))
+		base := y(min)
+		for i, v := range t.Values {
+			top := y(v)
+			fmt.Fprintf(sb, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s">%s</rect>`,
+				sparkNumber(float64(i)*barWidth+barWidth*0.1), sparkNumber(top), sparkNumber(barWidth*0.8), sparkNumber(base-top), color, title(i, v))
+		}
+		sb.WriteString(`</svg>`)
+		return sb.String()
+	}
+	x := func(i int) float64 {
+		if len(t.Values) == 1 {
+			return width / 2
+		}
+		return pad + (width-2*pad)*float64(i)/float64(len(t.Values)-1)
+	}
+	points := make([]string, len(t.Values))
+	for i, v := range t.Values {
+		points[i] = sparkNumber(x(i)) + "," + sparkNumber(y(v))
+	}
+	if t.Type == "area" {
+		// the polygon is closed to the baseline
+		base := sparkNumber(height - pad)
+		area := append([]string{sparkNumber(x(0)) + "," + base}, points...)
+		area = append(area, sparkNumber(x(len(t.Values)-1))+","+base)
+		fmt.Fprintf(sb, `<polygon points="%s" fill="%s" fill-opacity="0.25" stroke="none"/>`, strings.Join(area, " "), color)
+	}
+	fmt.Fprintf(sb, `<polyline points="%s" fill="none" stroke="%s" stroke-width="1.5" stroke-linejoin="round" stroke-linecap="round"/>`,
+		strings.Join(points, " "), color)
+	if t.Tooltip {
+		for i, v := range t.Values {
+			fmt.Fprintf(sb, `<circle cx="%s" cy="%s" r="3" fill="transparent">%s</circle>`, sparkNumber(x(i)), sparkNumber(y(v)), title(i, v))
+		}
+	}
+	if t.ShowDot {
+		last := len(t.Values) - 1
+		fmt.Fprintf(sb, `<circle cx="%s" cy="%s" r="2" fill="%s"/>`, sparkNumber(x(last)), sparkNumber(y(t.Values[last])), color)
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// SetValues change Values and Commit
+func (t *Sparkline) SetValues(v []float64) {
+	t.Values = v
+	t.Commit()
+}
@@ -0,0 +1,171 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type StepperStep struct {
+	ID          string
+	Label       string
+	Description string
+	Icon        string
+	// Status is "pending", "active", "completed" or "error"
+	Status string
+}
+
+type Stepper struct {
+	*liveview.ComponentDriver[*Stepper]
+	Steps       []StepperStep
+	CurrentStep int
+	// Clickable allow click the completed steps
+	Clickable   bool
+	OnStepClick func(index int)
+}
+
+var stepperColors = map[string]string{"pending": "#bdbdbd", "active": "#1976d2", "completed": "#2e7d32", "error": "#d32f2f"}
+
+func (t *Stepper) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Stepper) Start() {
+	t.syncStatus()
+	t.Commit()
+}
+
+func (t *Stepper) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="stepper" role="list" style="display:flex;align-items:flex-start">{{.RenderSteps}}</div>`
+}
+
+// syncStatus set the status of steps without status: completed before CurrentStep, active and pending after
+func (t *Stepper) syncStatus() {
+	for i := range t.Steps {
+		step := &t.Steps[i]
+		switch {
+		case step.Status == "error":
+		case i < t.CurrentStep:
+			step.Status = "completed"
+		case i == t.CurrentStep:
+			step.Status = "active"
+		case step.Status != "completed":
+			step.Status = "pending"
+		}
+	}
+}
+
+// RenderSteps return the circles of steps connected by lines colored by status
+func (t *Stepper) RenderSteps() string {
+	sb := &strings.Builder{}
+	for i, step := range t.Steps {
+		status := step.Status
+		if status == "" {
+			status = "pending"
+		}
+		color := stepperColors[status]
+		if i > 0 {
+			lineColor := stepperColors["pending"]
+			if t.Steps[i-1].Status == "completed" {
+				lineColor = stepperColors["completed"]
+			}
+			fmt.Fprintf(sb, `<div style="flex:1;height:2px;margin-top:15px;background:%s"></div>`, lineColor)
+		}
+		icon := strconv.Itoa(i + 1)
+		switch {
+		case status == "completed":
+			icon = "&#10003;"
+		case status == "error":
+			icon = "&times;"
+		case step.Icon != "":
+			icon = step.Icon
+		}
+		clickable := t.Clickable && status == "completed"
+		attrs := ""
+		if clickable {
+			attrs = fmt.Sprintf(` onclick="send_event('%s','StepClick','%d')" style="cursor:pointer;text-align:center;padding:0 8px"`, t.IdComponent, i)
+		} else {
+			attrs = ` style="text-align:center;padding:0 8px"`
+		}
+		current := ""
+		if i == t.CurrentStep {
+			current = ` aria-current="step"`
+		}
+		fmt.Fprintf(sb, `<div role="listitem" data-status="%s"%s%s>`, status, current, attrs)
+		background, fontColor := color, "#fff"
+		if status == "pending" {
+			background, fontColor = "#fff", color
+		}
+		fmt.Fprintf(sb, `<div style="width:28px;height:28px;line-height:28px;margin:0 auto;border-radius:50%%;border:2px solid %s;background:%s;color:%s">%s</div>`, color, background, fontColor, icon)
+		weight := "normal"
+		if status == "active" {
+			weight = "bold"
+		}
+		fmt.Fprintf(sb, `<div style="font-weight:%s">%s</div>`, weight, html.EscapeString(step.Label))
+		if step.Description != "" {
+			fmt.Fprintf(sb, `<small style="color:gray">%s</small>`, html.EscapeString(step.Description))
+		}
+		sb.WriteString(`</div>`)
+	}
+	return sb.String()
+}
+
+// GoTo set the current step and Commit
+func (t *Stepper) GoTo(index int) {
+	if index < 0 || index >= len(t.Steps) {
+		return
+	}
+	if index < t.CurrentStep {
+		// the steps after the new current are pending again
+		for i := index + 1; i < len(t.Steps); i++ {
+			if t.Steps[i].Status != "error" {
+				t.Steps[i].Status = "pending"
+			}
+		}
+	}
+	if t.Steps[index].Status == "error" {
+		t.Steps[index].Status = ""
+	}
+	t.CurrentStep = index
+	t.syncStatus()
+	t.Commit()
+}
+
+func (t *Stepper) Next() {
+	t.GoTo(t.CurrentStep + 1)
+}
+
+func (t *Stepper) Prev() {
+	t.GoTo(t.CurrentStep - 1)
+}
+
+// Complete mark the step as completed and Commit
+func (t *Stepper) Complete(index int) {
+	if index < 0 || index >= len(t.Steps) {
+		return
+	}
+	t.Steps[index].Status = "completed"
+	t.Commit()
+}
+
+// SetError mark the step with error and Commit
+func (t *Stepper) SetError(index int) {
+	if index < 0 || index >= len(t.Steps) {
+		return
+	}
+	t.Steps[index].Status = "error"
+	t.Commit()
+}
+
+func (t *Stepper) StepClick(data interface{}) {
+	index, err := strconv.Atoi(fmt.Sprint(data))
+	if err != nil || !t.Clickable || index < 0 || index >= len(t.Steps) || t.Steps[index].Status != "completed" {
+		return
+	}
+	if t.OnStepClick != nil {
+		t.OnStepClick(index)
+	}
+}
@@ -0,0 +1,150 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+// Switch is a sliding toggle, the checkbox is the element with IdComponent (it is hidden but it has the focus, so Space
+// toggles it and Enter is handled by onkeydown), the changes update the checkbox instead of rendering it again
+type Switch struct {
+	*liveview.ComponentDriver[*Switch]
+	Checked  bool
+	Label    string
+	OnLabel  string
+	OffLabel string
+	Color    string
+	// Size is "sm", "md" (default) or "lg"
+	Size     string
+	Disabled bool
+	OnChange func(checked bool)
+}
+
+func (t *Switch) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Switch) Start() {
+	if t.Color == "" {
+		t.Color = "#1976d2"
+	}
+	if t.Size != "sm" && t.Size != "lg" {
+		t.Size = "md"
+	}
+	t.Commit()
+}
+
+func (t *Switch) GetTemplate() string {
+	return `<label class="switch switch-{{.Size}}" style="--switch-color:{{html .Color}};{{if .Disabled}}opacity:.5;cursor:not-allowed{{end}}">
+	<style>
+		.switch { position:relative; display:inline-flex; align-items:center; gap:8px; cursor:pointer; }
+		.switch input { position:absolute; opacity:0; width:1px; height:1px; margin:0; }
+		.switch .switch-track { position:relative; display:inline-block; width:40px; height:22px; border-radius:11px; background:#bbb; transition:background .2s; }
+		.switch .switch-thumb { position:absolute; top:2px; left:2px; width:18px; height:18px; border-radius:50%; background:#fff; box-shadow:0 1px 2px rgba(0,0,0,.3); transition:transform .2s; }
+		.switch input:checked + .switch-track { background:var(--switch-color); }
+		.switch input:checked + .switch-track .switch-thumb { transform:translateX(18px); }
+		.switch input:focus-visible + .switch-track { outline:2px solid var(--switch-color); outline-offset:2px; }
+		.switch-sm .switch-track { width:28px; height:16px; border-radius:8px; }
+		.switch-sm .switch-thumb { width:12px; height:12px; }
+		.switch-sm input:checked + .switch-track .switch-thumb { transform:translateX(12px); }
+		.switch-lg .switch-track { width:52px; height:28px; border-radius:14px; }
+		.switch-lg .switch-thumb { width:24px; height:24px; }
+		.switch-lg input:checked + .switch-track .switch-thumb { transform:translateX(24px); }
+	</style>
+	<input type="checkbox" role="switch" id="{{.IdComponent}}" aria-checked="{{.Checked}}" {{if .Checked}}checked{{end}} {{if .Disabled}}disabled{{end}}
+		onchange="this.setAttribute('aria-checked', String(this.checked)); send_event(this.id, 'SwitchToggle', String(this.checked))"
+		onkeydown="if (event.key === 'Enter') { event.preventDefault(); this.click(); }"/>
+	<span class="switch-track"><span class="switch-thumb"></span></span>
+	{{if .Label}}<span>{{html .Label}}</span>{{end}}
+	<span id="{{.IdComponent}}_state" style="color:gray">{{html .StateLabel}}</span>
+</label>`
+}
+
+// StateLabel return OnLabel or OffLabel
+func (t *Switch) StateLabel() string {
+	if t.Checked {
+		return t.OnLabel
+	}
+	return t.OffLabel
+}
+
+// update change the checkbox and the state label in browser
+func (t *Switch) update() {
+	t.SetPropertie("checked", t.Checked)
+	t.SetPropertie("ariaChecked", strconv.FormatBool(t.Checked))
+	t.FillValueById(t.IdComponent+"_state", t.StateLabel())
+}
+
+// SwitchToggle is sent by the change of checkbox, data is "true" or "false"
+func (t *Switch) SwitchToggle(data interface{}) {
+	checked, err := strconv.ParseBool(fmt.Sprint(data))
+	if err != nil {
+		return
+	}
+	if t.Disabled {
+		t.update()
+		return
+	}
+	t.Checked = checked
+	t.FillValueById(t.IdComponent+"_state", t.StateLabel())
+	if t.OnChange != nil {
+		t.OnChange(t.Checked)
+	}
+}
+
+func (t *Switch) Toggle() {
+	t.Set(!t.Checked)
+}
+
+// Set change Checked, update the browser and call OnChange when it changes
+func (t *Switch) Set(checked bool) {
+	if t.Checked == checked {
+		return
+	}
+	t.Checked = checked
+	t.update()
+	if t.OnChange != nil {
+		t.OnChange(t.Checked)
+	}
+}
+
+// SwitchGroup show a list of switches under a heading, the switches are mounted with AddSwitch
+type SwitchGroup struct {
+	*liveview.ComponentDriver[*SwitchGroup]
+	Heading  string
+	Switches []*Switch
+}
+
+func (t *SwitchGroup) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *SwitchGroup) Start() {
+	t.Commit()
+}
+
+func (t *SwitchGroup) GetTemplate() string {
+	return `<fieldset id="{{.IdComponent}}" class="switch-group" role="group" style="border:1px solid #ddd;border-radius:4px;padding:8px 12px">
+	{{if .Heading}}<legend style="font-weight:bold">{{html .Heading}}</legend>{{end}}
+	{{range .Switches}}<div style="margin:6px 0">{{mount .IdComponent}}</div>{{end}}
+</fieldset>`
+}
+
+// AddSwitch create the driver of sw with id and mount it in the group, the group must have its driver
+func (t *SwitchGroup) AddSwitch(id string, sw *Switch) *Switch {
+	liveview.NewDriver(id, sw)
+	t.Mount(sw)
+	t.Switches = append(t.Switches, sw)
+	return sw
+}
+
+// Values return Checked of each switch by id
+func (t *SwitchGroup) Values() map[string]bool {
+	values := make(map[string]bool, len(t.Switches))
+	for _, sw := range t.Switches {
+		values[sw.IdComponent] = sw.Checked
+	}
+	return values
+}
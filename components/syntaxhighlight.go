@@ -0,0 +1,192 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+var syntaxKeywords = map[string][]string{
+	"go": {"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func", "go", "goto", "if",
+		"import", "interface", "map", "package", "range", "return", "select", "struct", "switch", "type", "var", "nil", "true", "false"},
+	"javascript": {"async", "await", "break", "case", "catch", "class", "const", "continue", "default", "delete", "do", "else", "export",
+		"extends", "false", "finally", "for", "function", "if", "import", "in", "instanceof", "let", "new", "null", "return", "switch",
+		"this", "throw", "true", "try", "typeof", "undefined", "var", "while", "yield"},
+	"python": {"and", "as", "assert", "async", "await", "break", "class", "continue", "def", "del", "elif", "else", "except", "False",
+		"finally", "for", "from", "global", "if", "import", "in", "is", "lambda", "None", "nonlocal", "not", "or", "pass", "raise",
+		"return", "True", "try", "while", "with", "yield"},
+	"sql": {"select", "from", "where", "insert", "into", "values", "update", "set", "delete", "create", "table", "drop", "alter", "join",
+		"left", "right", "inner", "outer", "on", "group", "by", "order", "having", "limit", "and", "or", "not", "null", "as", "distinct"},
+	"json": {"true", "false", "null"},
+	"bash": {"if", "then", "else", "elif", "fi", "for", "while", "do", "done", "case", "esac", "function", "return", "in", "export", "local"},
+}
+
+var syntaxAliases = map[string]string{"golang": "go", "js": "javascript", "ts": "javascript", "typescript": "javascript", "py": "python", "sh": "bash", "shell": "bash"}
+
+// syntaxThemes are the colors of comment, string, number, keyword, function and the background/foreground
+var syntaxThemes = map[string]map[string]string{
+	"monokai": {"c": "#75715e", "s": "#e6db74", "n": "#ae81ff", "k": "#f92672", "f": "#a6e22e", "bg": "#272822", "fg": "#f8f8f2", "hl": "#49483e", "ln": "#75715e"},
+	"github":  {"c": "#6a737d", "s": "#032f62", "n": "#005cc5", "k": "#d73a49", "f": "#6f42c1", "bg": "#f6f8fa", "fg": "#24292e", "hl": "#fffbdd", "ln": "#babbbc"},
+}
+
+// syntaxTokens are the tokens by language, the groups are comment, string, number, word and the ( of function
+var syntaxTokens = map[string]*regexp.Regexp{
+	"":       syntaxToken(`//[^\n]*|/\*.*?\*/`),
+	"python": syntaxToken(`#[^\n]*`),
+	"bash":   syntaxToken(`#[^\n]*`),
+	"sql":    syntaxToken(`--[^\n]*|/\*.*?\*/`),
+}
+
+func syntaxToken(comment string) *regexp.Regexp {
+	return regexp.MustCompile(`(?s)(` + comment + `)|("(?:\\.|[^"\\])*"|'(?:\\.|[^'\\])*'|` + "`[^`]*`" + `)|(\b\d+(?:\.\d+)?\b)|([A-Za-z_]\w*)(\s*\()?`)
+}
+
+type SyntaxHighlight struct {
+	*liveview.ComponentDriver[*SyntaxHighlight]
+	Code     string
+	Language string
+	// Theme is "monokai" (default) or "github"
+	Theme           string
+	ShowLineNumbers bool
+	// HighlightLines are the numbers of lines (from 1) with background
+	HighlightLines []int
+	CopyButton     bool
+	OnCopy         func()
+}
+
+func (t *SyntaxHighlight) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *SyntaxHighlight) Start() {
+	if _, ok := syntaxThemes[t.Theme]; !ok {
+		t.Theme = "monokai"
+	}
+	t.Commit()
+}
+
+func (t *SyntaxHighlight) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="syntax-highlight" style="position:relative">
+	<style>{{.ThemeCSS}}</style>
+	{{if .CopyButton}}<button style="position:absolute;top:4px;right:4px" onclick="send_event('{{.IdComponent}}', 'Copy')">Copy</button>{{end}}
+	<pre style="margin:0;padding:8px;overflow:auto"><code>{{.RenderCode}}</code></pre>
+</div>`
+}
+
+// ThemeCSS return the css of theme scoped to the component
+func (t *SyntaxHighlight) ThemeCSS() string {
+	theme := syntaxThemes[t.Theme]
+	if theme == nil {
+		theme = syntaxThemes["monokai"]
+	}
+	id := "#" + t.IdComponent
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "%s pre { background:%s; color:%s }\n", id, theme["bg"], theme["fg"])
+	for _, class := range []string{"c", "s", "n", "k", "f"} {
+		fmt.Fprintf(sb, "%s .hl-%s { color:%s }\n", id, class, theme[class])
+	}
+	fmt.Fprintf(sb, "%s .hl-line { display:block } %s .hl-mark { background:%s }\n", id, id, theme["hl"])
+	fmt.Fprintf(sb, "%s .hl-ln { display:inline-block; width:3em; color:%s; user-select:none }\n", id, theme["ln"])
+	return sb.String()
+}
+
+func (t *SyntaxHighlight) language() string {
+	lang := strings.ToLower(t.Language)
+	if alias, ok := syntaxAliases[lang]; ok {
+		return alias
+	}
+	return lang
+}
+
+// highlight return the code with spans of classes hl-c (comment), hl-s (string), hl-n (number), hl-k (keyword) and hl-f (function)
+func (t *SyntaxHighlight) highlight() string {
+	lang := t.language()
+	keywords := make(map[string]bool)
+	for _, k := range syntaxKeywords[lang] {
+		keywords[k] = true
+		if lang == "sql" {
+			keywords[strings.ToUpper(k)] = true
+		}
+	}
+	sb := &strings.Builder{}
+	last := 0
+	span := func(class, text string) {
+		// the span is closed in each line, so the code can be split by lines
+		for i, part := range strings.Split(text, "\n") {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			if part != "" {
+				sb.WriteString(`<span class="hl-` + class + `">` + html.EscapeString(part) + `</span>`)
+			}
+		}
+	}
+	token, ok := syntaxTokens[lang]
+	if !ok {
+		token = syntaxTokens[""]
+	}
+	for _, m := range token.FindAllStringSubmatchIndex(t.Code, -1) {
+		sb.WriteString(html.EscapeString(t.Code[last:m[0]]))
+		last = m[1]
+		text := t.Code[m[0]:m[1]]
+		switch {
+		case m[2] >= 0:
+			span("c", text)
+		case m[4] >= 0:
+			span("s", text)
+		case m[6] >= 0:
+			span("n", text)
+		default:
+			word := t.Code[m[8]:m[9]]
+			rest := t.Code[m[9]:m[1]]
+			switch {
+			case keywords[word]:
+				span("k", word)
+			case rest != "":
+				span("f", word)
+			default:
+				sb.WriteString(html.EscapeString(word))
+			}
+			sb.WriteString(html.EscapeString(rest))
+		}
+	}
+	sb.WriteString(html.EscapeString(t.Code[last:]))
+	return sb.String()
+}
+
+// RenderCode return the highlighted lines with numbers and marks
+func (t *SyntaxHighlight) RenderCode() string {
+	marks := make(map[int]bool)
+	for _, n := range t.HighlightLines {
+		marks[n] = true
+	}
+	sb := &strings.Builder{}
+	for i, line := range strings.Split(t.highlight(), "\n") {
+		class := "hl-line"
+		if marks[i+1] {
+			class += " hl-mark"
+		}
+		sb.WriteString(`<span class="` + class + `">`)
+		if t.ShowLineNumbers {
+			fmt.Fprintf(sb, `<span class="hl-ln">%d</span>`, i+1)
+		}
+		sb.WriteString(line + "</span>")
+	}
+	return sb.String()
+}
+
+// SetCode change the code and Commit
+func (t *SyntaxHighlight) SetCode(code string) {
+	t.Code = code
+	t.Commit()
+}
+
+func (t *SyntaxHighlight) Copy(data interface{}) {
+	t.CopyToClipboard(t.Code)
+	if t.OnCopy != nil {
+		t.OnCopy()
+	}
+}
@@ -0,0 +1,39 @@
+package components
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSyntaxHighlightComments(t *testing.T) {
+	tests := []struct {
+		lang string
+		code string
+		want []string
+		not  []string
+	}{
+		// the -- and # that are not comments of the language do not stop the highlight of the line
+		{"go", "i-- ; foo()", []string{`<span class="hl-f">foo</span>()`}, []string{"hl-c"}},
+		{"go", "x := a#b; return 1", []string{`<span class="hl-k">return</span>`, `<span class="hl-n">1</span>`}, []string{"hl-c"}},
+		{"js", "a-- // done", []string{`<span class="hl-c">// done</span>`}, nil},
+		{"python", "x = 1 # note", []string{`<span class="hl-n">1</span>`, `<span class="hl-c"># note</span>`}, nil},
+		{"python", "a // b", nil, []string{"hl-c"}},
+		{"bash", "echo 1 # note", []string{`<span class="hl-n">1</span>`, `<span class="hl-c"># note</span>`}, nil},
+		{"sql", "SELECT a-1 -- note", []string{`<span class="hl-k">SELECT</span>`, `<span class="hl-c">-- note</span>`}, nil},
+		{"sql", "/* a\nb */ select", []string{`<span class="hl-c">/* a</span>` + "\n" + `<span class="hl-c">b */</span>`}, nil},
+		{"json", `{"a": 1}`, []string{`<span class="hl-s">&#34;a&#34;</span>`}, []string{"hl-c"}},
+	}
+	for _, tt := range tests {
+		got := (&SyntaxHighlight{Language: tt.lang, Code: tt.code}).highlight()
+		for _, want := range tt.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("highlight(%s, %q) = %q, want it contains %q", tt.lang, tt.code, got, want)
+			}
+		}
+		for _, not := range tt.not {
+			if strings.Contains(got, not) {
+				t.Errorf("highlight(%s, %q) = %q has %q", tt.lang, tt.code, got, not)
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type TerminalLine struct {
+	Text string
+	// Kind is "input", "output" or "error"
+	Kind string
+}
+
+type Terminal struct {
+	*liveview.ComponentDriver[*Terminal]
+	PromptText string
+	History    []TerminalLine
+	// OnCommand return the output of command, it can write partial output with AppendLine
+	OnCommand func(command string) (string, error)
+	// MaxHistory is the max number of lines (default 500)
+	MaxHistory int
+	Height     string
+	commands   []string
+	mu         sync.Mutex
+}
+
+func (t *Terminal) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Terminal) Start() {
+	if t.PromptText == "" {
+		t.PromptText = "$ "
+	}
+	if t.MaxHistory == 0 {
+		t.MaxHistory = 500
+	}
+	if t.Height == "" {
+		t.Height = "300px"
+	}
+	t.Commit()
+}
+
+func (t *Terminal) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="terminal" style="background:#000;color:#ddd;font-family:monospace;padding:4px"
+	onclick="document.getElementById('{{.IdComponent}}_input').focus()">
+	<div id="{{.IdComponent}}_lines" style="height:{{.Height}};overflow:auto;white-space:pre-wrap">{{.RenderLines}}</div>
+	<div style="display:flex"><span>{{html .PromptText}}</span>
+		<input type="text" id="{{.IdComponent}}_input" autocomplete="off" spellcheck="false" data-history="{{.CommandsJSON}}"
+			style="flex:1;background:transparent;border:none;outline:none;color:inherit;font:inherit"
+			onkeydown="var h = JSON.parse(this.dataset.history), p = this.dataset.pos === undefined ? h.length : parseInt(this.dataset.pos);
+				if (event.key == 'Enter') {
+					send_event('{{.IdComponent}}', 'TerminalInput', this.value); this.value = ''; delete this.dataset.pos;
+				} else if (event.key == 'ArrowUp' || event.key == 'ArrowDown') {
+					event.preventDefault();
+					p = event.key == 'ArrowUp' ? Math.max(p - 1, 0) : Math.min(p + 1, h.length);
+					this.dataset.pos = p; this.value = p < h.length ? h[p] : '';
+				}"/>
+	</div>
+</div>`
+}
+
+// CommandsJSON return the executed commands as json array, it is used by up-arrow in the input (ClearScreen keeps them)
+func (t *Terminal) CommandsJSON() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	commands := t.commands
+	if commands == nil {
+		commands = []string{}
+	}
+	b, _ := json.Marshal(commands)
+	return html.EscapeString(string(b))
+}
+
+func (t *Terminal) renderLine(line TerminalLine) string {
+	switch line.Kind {
+	case "input":
+		return `<div>` + html.EscapeString(t.PromptText+line.Text) + `</div>`
+	case "error":
+		return `<div style="color:#f44336">` + ansiToHTML(line.Text) + `</div>`
+	}
+	return `<div>` + ansiToHTML(line.Text) + `</div>`
+}
+
+// RenderLines return the html of History
+func (t *Terminal) RenderLines() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sb := &strings.Builder{}
+	for _, line := range t.History {
+		sb.WriteString(t.renderLine(line))
+	}
+	return sb.String()
+}
+
+func (t *Terminal) add(line TerminalLine) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.History = append(t.History, line)
+	if t.MaxHistory > 0 && len(t.History) > t.MaxHistory {
+		t.History = t.History[len(t.History)-t.MaxHistory:]
+	}
+}
+
+func (t *Terminal) scrollBottom() {
+	t.EvalScript(fmt.Sprintf(`var e = document.getElementById("%s_lines"); if (e) { e.scrollTop = e.scrollHeight; }`, t.IdComponent))
+}
+
+// AppendLine add a line (the text can be multi-line) and append it to the page without render all history,
+// it is useful for stream the output of long-running commands
+func (t *Terminal) AppendLine(kind string, text string) {
+	for _, s := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		line := TerminalLine{Text: s, Kind: kind}
+		t.add(line)
+		t.AppendHTML(t.IdComponent+"_lines", t.renderLine(line))
+	}
+	t.scrollBottom()
+}
+
+func (t *Terminal) TerminalInput(data interface{}) {
+	t.Stdin(fmt.Sprint(data))
+}
+
+// Stdin execute command as if the user typed it
+func (t *Terminal) Stdin(text string) {
+	t.AppendLine("input", text)
+	if strings.TrimSpace(text) != "" {
+		t.mu.Lock()
+		t.commands = append(t.commands, text)
+		if t.MaxHistory > 0 && len(t.commands) > t.MaxHistory {
+			t.commands = t.commands[1:]
+		}
+		t.mu.Unlock()
+	}
+	if t.OnCommand != nil && strings.TrimSpace(text) != "" {
+		out, err := t.OnCommand(text)
+		if out != "" {
+			t.AppendLine("output", out)
+		}
+		if err != nil {
+			t.AppendLine("error", err.Error())
+		}
+	}
+	t.Commit()
+	t.scrollBottom()
+	t.FocusElement(t.IdComponent+"_input", false)
+}
+
+func (t *Terminal) ClearScreen() {
+	t.mu.Lock()
+	t.History = nil
+	t.mu.Unlock()
+	t.Commit()
+}
@@ -0,0 +1,210 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type TimelineEvent struct {
+	ID          string
+	Title       string
+	Description string
+	Timestamp   time.Time
+	Icon        string
+	Color       string
+	Meta        map[string]string
+}
+
+type Timeline struct {
+	*liveview.ComponentDriver[*Timeline]
+	Events  []TimelineEvent
+	Loading bool
+	HasMore bool
+	// PageSize is the limit passed to OnLoadMore (default 20)
+	PageSize int
+	// OnLoadMore receive the timestamp of oldest event and return the events before it
+	OnLoadMore func(before time.Time, limit int) ([]TimelineEvent, bool, error)
+	// Reverse show the newest events first
+	Reverse     bool
+	GroupByDate bool
+	Error       string
+	// added are the ids of events added since last render, they are animated
+	added map[string]bool
+}
+
+func (t *Timeline) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *Timeline) Start() {
+	if t.PageSize == 0 {
+		t.PageSize = 20
+	}
+	if t.OnLoadMore != nil && t.Events == nil {
+		t.HasMore = true
+	}
+	t.Commit()
+}
+
+func (t *Timeline) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="timeline">
+	<style>
+	@keyframes timeline-slide-down { from { opacity:0; transform:translateY(-12px) } to { opacity:1; transform:none } }
+	#{{.IdComponent}} .timeline-new { animation: timeline-slide-down .4s ease-out }
+	</style>
+	{{if .Reverse}}{{.RenderEvents}}{{end}}
+	{{if .Loading}}
+	<div class="timeline-loading" style="text-align:center;color:gray;padding:8px">Loading...</div>
+	{{else if .Error}}
+	<div class="timeline-error" style="color:red">{{html .Error}} <button onclick="send_event('{{.IdComponent}}', 'LoadMore')">Retry</button></div>
+	{{else if .HasMore}}
+	<div style="text-align:center;padding:8px"><button onclick="send_event('{{.IdComponent}}', 'LoadMore')">Load more</button></div>
+	{{end}}
+	{{if not .Reverse}}{{.RenderEvents}}{{end}}
+</div>`
+}
+
+// RelativeTime return t relative to now, e.g. "2 hours ago" or "in 5 minutes"
+func RelativeTime(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Minute {
+		return "just now"
+	}
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{365 * 24 * time.Hour, "year"},
+		{30 * 24 * time.Hour, "month"},
+		{7 * 24 * time.Hour, "week"},
+		{24 * time.Hour, "day"},
+		{time.Hour, "hour"},
+		{time.Minute, "minute"},
+	}
+	for _, u := range units {
+		if d < u.size {
+			continue
+		}
+		n := int(d / u.size)
+		text := fmt.Sprintf("%d %s", n, u.name)
+		if n > 1 {
+			text += "s"
+		}
+		if future {
+			return "in " + text
+		}
+		return text + " ago"
+	}
+	return "just now"
+}
+
+// sorted return the events ordered by timestamp, newest first with Reverse
+func (t *Timeline) sorted() []TimelineEvent {
+	events := append([]TimelineEvent{}, t.Events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		if t.Reverse {
+			return events[i].Timestamp.After(events[j].Timestamp)
+		}
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events
+}
+
+// RenderEvents return the html of events with a separator per date if GroupByDate
+func (t *Timeline) RenderEvents() string {
+	now := time.Now()
+	sb := &strings.Builder{}
+	sb.WriteString(`<ul style="list-style:none;margin:0;padding:0 0 0 16px;border-left:2px solid #ddd">`)
+	lastDate := ""
+	for _, e := range t.sorted() {
+		if t.GroupByDate {
+			date := e.Timestamp.Format("2006-01-02")
+			if date != lastDate {
+				fmt.Fprintf(sb, `<li class="timeline-date" style="margin:12px 0 4px -16px;font-weight:bold;color:#555">%s</li>`, e.Timestamp.Format("Monday, January 2, 2006"))
+				lastDate = date
+			}
+		}
+		color := e.Color
+		if color == "" {
+			color = "#1976d2"
+		}
+		class := "timeline-event"
+		if t.added[e.ID] {
+			class += " timeline-new"
+		}
+		fmt.Fprintf(sb, `<li id="%s_%s" class="%s" style="position:relative;padding:6px 0 6px 16px">`, t.IdComponent, html.EscapeString(e.ID), class)
+		fmt.Fprintf(sb, `<span style="position:absolute;left:-29px;top:6px;width:24px;height:24px;border-radius:50%%;background:%s;color:#fff;text-align:center;line-height:24px;font-size:12px">%s</span>`,
+			html.EscapeString(color), html.EscapeString(e.Icon))
+		fmt.Fprintf(sb, `<div style="border-left:3px solid %s;padding-left:8px"><strong>%s</strong> <small style="color:gray" title="%s">%s</small>`,
+			html.EscapeString(color), html.EscapeString(e.Title), e.Timestamp.Format(time.RFC3339), RelativeTime(e.Timestamp, now))
+		if e.Description != "" {
+			sb.WriteString(`<div>` + html.EscapeString(e.Description) + `</div>`)
+		}
+		if len(e.Meta) > 0 {
+			keys := make([]string, 0, len(e.Meta))
+			for k := range e.Meta {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			sb.WriteString(`<div style="font-size:12px;color:#666">`)
+			for _, k := range keys {
+				fmt.Fprintf(sb, `<span style="margin-right:8px">%s: %s</span>`, html.EscapeString(k), html.EscapeString(e.Meta[k]))
+			}
+			sb.WriteString(`</div>`)
+		}
+		sb.WriteString(`</div></li>`)
+	}
+	sb.WriteString(`</ul>`)
+	// the animation is only for the first render of new events
+	t.added = nil
+	return sb.String()
+}
+
+// AddEvent add a new event with the slide-down animation and Commit
+func (t *Timeline) AddEvent(e TimelineEvent) {
+	if t.added == nil {
+		t.added = make(map[string]bool)
+	}
+	t.added[e.ID] = true
+	t.Events = append(t.Events, e)
+	t.Commit()
+}
+
+func (t *Timeline) oldest() time.Time {
+	oldest := time.Now()
+	for _, e := range t.Events {
+		if e.Timestamp.Before(oldest) {
+			oldest = e.Timestamp
+		}
+	}
+	return oldest
+}
+
+// LoadMore is sent by the "Load more" button, the older events are added to the end (or the start without Reverse)
+func (t *Timeline) LoadMore(data interface{}) {
+	if t.Loading || t.OnLoadMore == nil {
+		return
+	}
+	t.Loading = true
+	t.Error = ""
+	t.Commit()
+	events, hasMore, err := t.OnLoadMore(t.oldest(), t.PageSize)
+	t.Loading = false
+	if err != nil {
+		t.Error = err.Error()
+		t.Commit()
+		return
+	}
+	t.Events = append(t.Events, events...)
+	t.HasMore = hasMore
+	t.Commit()
+}
@@ -0,0 +1,209 @@
+package components
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"text/template"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type TreeNode struct {
+	ID       string
+	Label    string
+	Children []TreeNode
+	Expanded bool
+	// Checked nil is without checkbox
+	Checked *bool
+	Icon    string
+	Data    map[string]interface{}
+}
+
+type TreeView struct {
+	*liveview.ComponentDriver[*TreeView]
+	Nodes    []TreeNode
+	Selected string
+	// Lazy send LoadChildren when a node without children is expanded
+	Lazy           bool
+	OnToggle       func(nodeID string, expanded bool)
+	OnCheck        func(nodeID string, checked bool)
+	OnSelect       func(nodeID string)
+	OnLoadChildren func(nodeID string) []TreeNode
+}
+
+func (t *TreeView) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *TreeView) Start() {
+	t.Commit()
+}
+
+func (t *TreeView) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="tree-view">{{.RenderNodes}}</div>`
+}
+
+// RenderNodes return the html of nodes as nested ul/li
+func (t *TreeView) RenderNodes() string {
+	sb := &strings.Builder{}
+	t.renderNodes(sb, t.Nodes)
+	return sb.String()
+}
+
+func (t *TreeView) renderNodes(sb *strings.Builder, nodes []TreeNode) {
+	sb.WriteString(`<ul style="list-style:none;padding-left:16px">`)
+	for _, node := range nodes {
+		// id is inside a js string of onclick
+		id := html.EscapeString(template.JSEscapeString(node.ID))
+		sb.WriteString(`<li>`)
+		if len(node.Children) > 0 || t.Lazy {
+			arrow, event := "&#9656;", "Toggle"
+			if node.Expanded {
+				arrow = "&#9662;"
+			}
+			if t.Lazy && len(node.Children) == 0 && !node.Expanded {
+				event = "LoadChildren"
+			}
+			fmt.Fprintf(sb, `<span style="cursor:pointer" onclick="send_event('%s','%s','%s')">%s</span> `, t.IdComponent, event, id, arrow)
+		} else {
+			sb.WriteString(`<span style="visibility:hidden">&#9656;</span> `)
+		}
+		if node.Checked != nil {
+			checked := ""
+			if *node.Checked {
+				checked = "checked"
+			}
+			fmt.Fprintf(sb, `<input type="checkbox" %s onclick="send_event('%s','Check','%s')"/> `, checked, t.IdComponent, id)
+		}
+		if node.Icon != "" {
+			sb.WriteString(node.Icon + " ")
+		}
+		style := "cursor:pointer"
+		if node.ID == t.Selected {
+			style += ";font-weight:bold"
+		}
+		fmt.Fprintf(sb, `<span style="%s" onclick="send_event('%s','Select','%s')">%s</span>`, style, t.IdComponent, id, html.EscapeString(node.Label))
+		if node.Expanded && len(node.Children) > 0 {
+			t.renderNodes(sb, node.Children)
+		}
+		sb.WriteString(`</li>`)
+	}
+	sb.WriteString(`</ul>`)
+}
+
+// FindNode return the node with id or nil
+func (t *TreeView) FindNode(id string) *TreeNode {
+	return findTreeNode(t.Nodes, id)
+}
+
+func findTreeNode(nodes []TreeNode, id string) *TreeNode {
+	for i := range nodes {
+		if nodes[i].ID == id {
+			return &nodes[i]
+		}
+		if node := findTreeNode(nodes[i].Children, id); node != nil {
+			return node
+		}
+	}
+	return nil
+}
+
+// Search return the ids of nodes with label that contains query (case insensitive)
+func (t *TreeView) Search(query string) []string {
+	ids := make([]string, 0)
+	query = strings.ToLower(query)
+	var search func(nodes []TreeNode)
+	search = func(nodes []TreeNode) {
+		for _, node := range nodes {
+			if strings.Contains(strings.ToLower(node.Label), query) {
+				ids = append(ids, node.ID)
+			}
+			search(node.Children)
+		}
+	}
+	search(t.Nodes)
+	return ids
+}
+
+func (t *TreeView) Toggle(data interface{}) {
+	node := t.FindNode(fmt.Sprint(data))
+	if node == nil {
+		return
+	}
+	node.Expanded = !node.Expanded
+	if t.OnToggle != nil {
+		t.OnToggle(node.ID, node.Expanded)
+	}
+	t.Commit()
+}
+
+func (t *TreeView) LoadChildren(data interface{}) {
+	node := t.FindNode(fmt.Sprint(data))
+	if node == nil {
+		return
+	}
+	if t.OnLoadChildren != nil {
+		node.Children = t.OnLoadChildren(node.ID)
+	}
+	node.Expanded = true
+	if t.OnToggle != nil {
+		t.OnToggle(node.ID, true)
+	}
+	t.Commit()
+}
+
+func (t *TreeView) Check(data interface{}) {
+	node := t.FindNode(fmt.Sprint(data))
+	if node == nil || node.Checked == nil {
+		return
+	}
+	checked := !*node.Checked
+	setTreeChecked(node, checked)
+	syncTreeChecked(t.Nodes)
+	if t.OnCheck != nil {
+		t.OnCheck(node.ID, checked)
+	}
+	t.Commit()
+}
+
+// setTreeChecked check the node and all children
+func setTreeChecked(node *TreeNode, checked bool) {
+	if node.Checked != nil {
+		value := checked
+		node.Checked = &value
+	}
+	for i := range node.Children {
+		setTreeChecked(&node.Children[i], checked)
+	}
+}
+
+// syncTreeChecked set the parents checked only if all children with checkbox are checked
+func syncTreeChecked(nodes []TreeNode) {
+	for i := range nodes {
+		node := &nodes[i]
+		syncTreeChecked(node.Children)
+		if node.Checked == nil {
+			continue
+		}
+		all, found := true, false
+		for _, child := range node.Children {
+			if child.Checked != nil {
+				found = true
+				all = all && *child.Checked
+			}
+		}
+		if found {
+			value := all
+			node.Checked = &value
+		}
+	}
+}
+
+func (t *TreeView) Select(data interface{}) {
+	t.Selected = fmt.Sprint(data)
+	if t.OnSelect != nil {
+		t.OnSelect(t.Selected)
+	}
+	t.Commit()
+}
@@ -0,0 +1,139 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+// VideoPlayer is a video controlled by the server, the video element is rendered in the first Commit and the next
+// Commits send scripts for the changes (so the playback is not restarted)
+type VideoPlayer struct {
+	*liveview.ComponentDriver[*VideoPlayer]
+	Src          string
+	Poster       string
+	Playing      bool
+	CurrentTime  float64
+	Muted        bool
+	Loop         bool
+	Controls     bool
+	Width        string
+	OnTimeUpdate func(currentTime float64)
+	OnEnded      func()
+	initialized  bool
+	sent         VideoPlayerState
+}
+
+// VideoPlayerState are the fields of VideoPlayer sent to the browser
+type VideoPlayerState struct {
+	Src     string
+	Poster  string
+	Playing bool
+	Muted   bool
+	Loop    bool
+}
+
+func (t *VideoPlayer) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *VideoPlayer) Start() {
+	if t.Width == "" {
+		t.Width = "100%"
+	}
+	// Start is called again on reconnect, the video resumes from CurrentTime
+	t.initialized = false
+	t.Commit()
+}
+
+func (t *VideoPlayer) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="video-player">
+	<video id="{{.IdComponent}}_video" data-video-component="{{.IdComponent}}" src="{{html .Src}}" {{if .Poster}}poster="{{html .Poster}}"{{end}}
+		{{if .Muted}}muted{{end}} {{if .Loop}}loop{{end}} {{if .Controls}}controls{{end}} playsinline style="width:{{.Width}}"></video>
+</div>`
+}
+
+func (t *VideoPlayer) state() VideoPlayerState {
+	return VideoPlayerState{Src: t.Src, Poster: t.Poster, Playing: t.Playing, Muted: t.Muted, Loop: t.Loop}
+}
+
+func (t *VideoPlayer) video() string {
+	return "document.getElementById(" + jsJSON(t.IdComponent+"_video") + ")"
+}
+
+// Commit render the video the first time and then send the changes with scripts
+func (t *VideoPlayer) Commit() {
+	state := t.state()
+	if !t.initialized {
+		t.ComponentDriver.Commit()
+		t.initialized = true
+		t.sent = VideoPlayerState{Src: t.Src, Poster: t.Poster, Muted: t.Muted, Loop: t.Loop}
+		if t.CurrentTime > 0 {
+			t.EvalScript(fmt.Sprintf("%s.currentTime = %g", t.video(), t.CurrentTime))
+		}
+	}
+	if state.Src != t.sent.Src {
+		t.EvalScript(fmt.Sprintf("%s.src = %s", t.video(), jsJSON(t.Src)))
+	}
+	if state.Poster != t.sent.Poster {
+		t.EvalScript(fmt.Sprintf("%s.poster = %s", t.video(), jsJSON(t.Poster)))
+	}
+	if state.Muted != t.sent.Muted {
+		t.EvalScript(fmt.Sprintf("%s.muted = %t", t.video(), t.Muted))
+	}
+	if state.Loop != t.sent.Loop {
+		t.EvalScript(fmt.Sprintf("%s.loop = %t", t.video(), t.Loop))
+	}
+	if state.Playing != t.sent.Playing || (state.Src != t.sent.Src && t.Playing) {
+		if t.Playing {
+			// play is rejected by the browser without user interaction unless the video is muted
+			t.EvalScript(t.video() + ".play().catch(function(e) { console.log(e) })")
+		} else {
+			t.EvalScript(t.video() + ".pause()")
+		}
+	}
+	t.sent = state
+}
+
+func (t *VideoPlayer) Play() {
+	t.Playing = true
+	t.Commit()
+}
+
+func (t *VideoPlayer) Pause() {
+	t.Playing = false
+	t.Commit()
+}
+
+// SetCurrentTime seek the video to seconds
+func (t *VideoPlayer) SetCurrentTime(seconds float64) {
+	t.CurrentTime = seconds
+	t.EvalScript(fmt.Sprintf("%s.currentTime = %g", t.video(), seconds))
+}
+
+// VideoProgress is sent by the wasm each 500ms while the video is playing
+func (t *VideoPlayer) VideoProgress(data interface{}) {
+	seconds, err := strconv.ParseFloat(fmt.Sprint(data), 64)
+	if err != nil {
+		return
+	}
+	t.CurrentTime = seconds
+	if t.OnTimeUpdate != nil {
+		t.OnTimeUpdate(seconds)
+	}
+}
+
+func (t *VideoPlayer) VideoEnded(data interface{}) {
+	t.Playing = false
+	t.sent.Playing = false
+	if t.OnEnded != nil {
+		t.OnEnded()
+	}
+}
+
+// VideoState is sent when the video is played or paused in the browser (example with the controls)
+func (t *VideoPlayer) VideoState(data interface{}) {
+	t.Playing = fmt.Sprint(data) == "true"
+	t.sent.Playing = t.Playing
+}
@@ -0,0 +1,191 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+)
+
+type VirtualList struct {
+	*liveview.ComponentDriver[*VirtualList]
+	// ItemHeight is the height in px of each item (default 30), ItemHeights rewrite it for variable heights
+	ItemHeight  int
+	ItemHeights []int
+	// Items are used when OnFetchItems is nil
+	Items []interface{}
+	// TotalItems is the count of items (default len(Items))
+	TotalItems   int
+	OnFetchItems func(start, end int) ([]interface{}, error)
+	RenderItem   func(item interface{}) string
+	// Height is the height in px of the list (default 400)
+	Height int
+	// Overscan is the count of items rendered out of the view (default 5)
+	Overscan  int
+	Error     string
+	start     int
+	end       int
+	scrollTop float64
+}
+
+func (t *VirtualList) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *VirtualList) Start() {
+	if t.ItemHeight == 0 {
+		t.ItemHeight = 30
+	}
+	if t.Height == 0 {
+		t.Height = 400
+	}
+	if t.Overscan == 0 {
+		t.Overscan = 5
+	}
+	if t.TotalItems == 0 {
+		t.TotalItems = len(t.Items)
+	}
+	t.scrollTop = 0
+	t.Commit()
+}
+
+func (t *VirtualList) GetTemplate() string {
+	return `<div id="{{.IdComponent}}" class="virtual-list">
+	<div id="{{.IdComponent}}_viewport" data-virtual-component="{{.IdComponent}}" style="height:{{.Height}}px;overflow-y:auto;position:relative">
+		<div id="{{.IdComponent}}_spacer" style="height:{{.TotalHeight}}px"></div>
+		<div id="{{.IdComponent}}_items" style="position:absolute;left:0;right:0;top:0">{{.RenderItems}}</div>
+	</div>
+	{{if .Error}}<div class="virtual-list-error" style="color:red">{{html .Error}}</div>{{end}}
+</div>`
+}
+
+func (t *VirtualList) heightOf(i int) int {
+	if i < len(t.ItemHeights) && t.ItemHeights[i] > 0 {
+		return t.ItemHeights[i]
+	}
+	return t.ItemHeight
+}
+
+// Offset return the top in px of item index
+func (t *VirtualList) Offset(index int) int {
+	if len(t.ItemHeights) == 0 {
+		return index * t.ItemHeight
+	}
+	top := 0
+	for i := 0; i < index; i++ {
+		top += t.heightOf(i)
+	}
+	return top
+}
+
+func (t *VirtualList) TotalHeight() int {
+	return t.Offset(t.TotalItems)
+}
+
+// indexAt return the index of item in the position top (px)
+func (t *VirtualList) indexAt(top float64) int {
+	if len(t.ItemHeights) == 0 {
+		return int(top) / t.ItemHeight
+	}
+	offset := 0
+	for i := 0; i < t.TotalItems; i++ {
+		offset += t.heightOf(i)
+		if float64(offset) > top {
+			return i
+		}
+	}
+	return t.TotalItems
+}
+
+// visibleRange return [start, end) of items in the view with Overscan
+func (t *VirtualList) visibleRange() (int, int) {
+	start := t.indexAt(t.scrollTop) - t.Overscan
+	end := t.indexAt(t.scrollTop+float64(t.Height)) + 1 + t.Overscan
+	if start < 0 {
+		start = 0
+	}
+	if end > t.TotalItems {
+		end = t.TotalItems
+	}
+	if start > end {
+		start = end
+	}
+	return start, end
+}
+
+func (t *VirtualList) fetch(start, end int) []interface{} {
+	t.Error = ""
+	if t.OnFetchItems != nil {
+		items, err := t.OnFetchItems(start, end)
+		if err != nil {
+			t.Error = err.Error()
+		}
+		return items
+	}
+	if end > len(t.Items) {
+		end = len(t.Items)
+	}
+	if start >= end {
+		return nil
+	}
+	return t.Items[start:end]
+}
+
+// RenderItems return the html of items in the view, the container is moved to the top of first item
+func (t *VirtualList) RenderItems() string {
+	start, end := t.visibleRange()
+	t.start, t.end = start, end
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, `<div style="transform:translateY(%dpx)">`, t.Offset(start))
+	for i, item := range t.fetch(start, end) {
+		content := ""
+		if t.RenderItem != nil {
+			content = t.RenderItem(item)
+		} else {
+			content = html.EscapeString(fmt.Sprint(item))
+		}
+		fmt.Fprintf(sb, `<div data-index="%d" style="height:%dpx;overflow:hidden">%s</div>`, start+i, t.heightOf(start+i), content)
+	}
+	sb.WriteString(`</div>`)
+	return sb.String()
+}
+
+func (t *VirtualList) VirtualScroll(data interface{}) {
+	var scroll struct {
+		ScrollTop    float64 `json:"scrollTop"`
+		ClientHeight float64 `json:"clientHeight"`
+	}
+	if err := json.Unmarshal([]byte(fmt.Sprint(data)), &scroll); err != nil {
+		return
+	}
+	t.scrollTop = scroll.ScrollTop
+	if start, end := t.visibleRange(); start == t.start && end == t.end {
+		return
+	}
+	// only the items are filled for keep the scroll of viewport
+	t.FillValueById(t.IdComponent+"_items", t.RenderItems())
+}
+
+// ScrollTo scroll the list to the item index
+func (t *VirtualList) ScrollTo(index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index > t.TotalItems {
+		index = t.TotalItems
+	}
+	t.scrollTop = float64(t.Offset(index))
+	t.FillValueById(t.IdComponent+"_items", t.RenderItems())
+	t.EvalScript(fmt.Sprintf(`var e = document.getElementById("%s_viewport"); if (e) { e.scrollTop = %d; }`, t.IdComponent, t.Offset(index)))
+}
+
+// Refresh render again the items in the view, it keeps the scroll (Commit moves the list to the top)
+func (t *VirtualList) Refresh() {
+	if t.OnFetchItems == nil && t.TotalItems < len(t.Items) {
+		t.TotalItems = len(t.Items)
+	}
+	t.FillValueById(t.IdComponent+"_items", t.RenderItems())
+	t.EvalScript(fmt.Sprintf(`var e = document.getElementById("%s_spacer"); if (e) { e.style.height = "%dpx"; }`, t.IdComponent, t.TotalHeight()))
+}
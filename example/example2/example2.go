@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -46,7 +47,7 @@ func main() {
 			</select>`)
 		liveview.New("text_msg", &components.InputText{})
 		liveview.New("text_nickname", &components.InputText{}).
-			SetEvent("Change", func(this *components.InputText, data interface{}) {
+			SetEvent("Change", func(this *components.InputText, ctx context.Context, data interface{}) {
 				userMutex.Lock()
 				defer userMutex.Unlock()
 				if _, ok := bUser.GetByValue(this.GetValue()); ok {
@@ -59,7 +60,7 @@ func main() {
 				liveview.SendToAllLayouts("NEW_USER")
 			})
 		liveview.New("button_send", &components.Button{Caption: "Send"}).
-			SetClick(func(this *components.Button, data interface{}) {
+			SetClick(func(this *components.Button, ctx context.Context, data interface{}) {
 				userMutex.Lock()
 				defer userMutex.Unlock()
 				if nickname, ok := bUser.Get(document.Component.UUID); ok {
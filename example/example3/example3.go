@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/arturoeanton/go-echo-live-view/components"
@@ -28,12 +29,12 @@ func main() {
 		document := liveview.NewLayout("layout1", "example/example3/layout.html")
 		liveview.New("span_result", &liveview.None{})
 		liveview.New("div_text_result", &liveview.None{})
-		liveview.New("text1", &components.InputText{}).SetKeyUp(func(text1 *components.InputText, data interface{}) {
+		liveview.New("text1", &components.InputText{}).SetKeyUp(func(text1 *components.InputText, ctx context.Context, data interface{}) {
 			divTextResult := document.GetDriverById("div_text_result")
 			divTextResult.FillValue(text1.GetValue())
 		})
 
-		liveview.New("button1", &components.Button{Caption: "Sum 1"}).SetClick(func(button1 *components.Button, data interface{}) {
+		liveview.New("button1", &components.Button{Caption: "Sum 1"}).SetClick(func(button1 *components.Button, ctx context.Context, data interface{}) {
 			button1.I++
 			spanResult := document.GetDriverById("span_result")
 			text1 := document.GetDriverById("text1")
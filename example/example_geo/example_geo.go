@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+type LiveGeoComponent struct {
+	*liveview.ComponentDriver[*LiveGeoComponent]
+	Lat      float64
+	Lon      float64
+	Accuracy float64
+	Error    string
+	Watching bool
+}
+
+func (t *LiveGeoComponent) GetDriver() liveview.LiveDriver {
+	return t
+}
+
+func (t *LiveGeoComponent) Start() {
+	t.Commit()
+}
+
+func (t *LiveGeoComponent) GetTemplate() string {
+	return `<div id="{{.IdComponent}}">
+		<button onclick="RequestGeolocation('{{.IdComponent}}')">Where am I?</button>
+		<button onclick="window.geoWatch = RequestGeolocationWatch('{{.IdComponent}}')">Follow me</button>
+		<button onclick="ClearGeolocationWatch(window.geoWatch)">Stop</button>
+		{{if .Error}}<div style="color:red">{{.Error}}</div>{{end}}
+		{{if .Accuracy}}
+		<div>Lat: {{.Lat}} Lon: {{.Lon}} (±{{.Accuracy}}m)</div>
+		<iframe width="600" height="400" frameborder="0"
+			src="https://www.openstreetmap.org/export/embed.html?bbox={{.Lon}},{{.Lat}},{{.Lon}},{{.Lat}}&layer=mapnik&marker={{.Lat}},{{.Lon}}"></iframe>
+		{{end}}
+	</div>`
+}
+
+func (t *LiveGeoComponent) Geolocation(data interface{}) {
+	var geo struct {
+		Lat      float64 `json:"lat"`
+		Lon      float64 `json:"lon"`
+		Accuracy float64 `json:"accuracy"`
+	}
+	if err := json.Unmarshal([]byte(fmt.Sprint(data)), &geo); err != nil {
+		fmt.Println(err)
+		return
+	}
+	t.Lat, t.Lon, t.Accuracy, t.Error = geo.Lat, geo.Lon, geo.Accuracy, ""
+	t.Commit()
+}
+
+func (t *LiveGeoComponent) GeolocationError(data interface{}) {
+	var geoError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	json.Unmarshal([]byte(fmt.Sprint(data)), &geoError)
+	t.Error = geoError.Message
+	t.Commit()
+}
+
+func main() {
+	e := echo.New()
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	home := liveview.PageControl{
+		Title:  "Geolocation",
+		Lang:   "en",
+		Path:   "/",
+		Router: e,
+	}
+	home.Register(func() liveview.LiveDriver {
+		liveview.New("geo", &LiveGeoComponent{})
+		return liveview.NewLayout("home", `<div> {{mount "geo"}} </div>`)
+	})
+	e.Logger.Fatal(e.Start(":1323"))
+}
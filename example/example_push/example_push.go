@@ -0,0 +1,60 @@
+package main
+
+/*
+HTTP/2 needs TLS, create cert.pem and key.pem for localhost with:
+
+	go run "$(go env GOROOT)/src/crypto/tls/generate_cert.go" --host localhost
+
+Run with -push=true and -push=false and open https://localhost:1323/ in a new private window each time (the cookie
+liveview_pushed stops the push in the next visits), the page shows the time to DOMContentLoaded and to json.wasm.
+*/
+
+import (
+	"flag"
+
+	"github.com/arturoeanton/go-echo-live-view/components"
+	"github.com/arturoeanton/go-echo-live-view/liveview"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const timingScript = `<div id="timing" style="font-family:monospace;margin-top:16px"></div>
+<script>
+window.addEventListener("load", function() {
+	setTimeout(function() {
+		var nav = performance.getEntriesByType("navigation")[0];
+		var wasm = performance.getEntriesByType("resource").filter(function(r) { return r.name.indexOf("json.wasm") >= 0; })[0];
+		var text = "DOMContentLoaded: " + nav.domContentLoadedEventEnd.toFixed(1) + "ms";
+		if (wasm) { text += " | json.wasm: " + wasm.responseEnd.toFixed(1) + "ms (" + (wasm.transferSize ? "network" : "cache or push") + ")"; }
+		document.getElementById("timing").innerText = text;
+		console.log(text);
+	}, 0);
+});
+</script>`
+
+func main() {
+	push := flag.Bool("push", true, "enable HTTP/2 server push")
+	cert := flag.String("cert", "cert.pem", "TLS certificate")
+	key := flag.String("key", "key.pem", "TLS key")
+	flag.Parse()
+
+	e := echo.New()
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	home := liveview.PageControl{
+		Title:           "Example HTTP/2 push",
+		Path:            "/",
+		Router:          e,
+		EnableHTTP2Push: *push,
+		AfterCode:       timingScript,
+	}
+
+	home.Register(func() liveview.LiveDriver {
+		liveview.New("clock1", &components.Clock{})
+		return liveview.NewLayout("layout1", `<div>{{mount "clock1"}}</div>`)
+	})
+
+	e.Logger.Fatal(e.StartTLS(":1323", *cert, *key))
+}
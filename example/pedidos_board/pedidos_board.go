@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -122,10 +123,10 @@ func main() {
 			<hr/>
 			<div id="div_status"></div>`)
 		liveview.New("button_send", &components.Button{Caption: "Actualizar"}).
-			SetClick(func(this *components.Button, data interface{}) {
+			SetClick(func(this *components.Button, ctx context.Context, data interface{}) {
 				liveview.SendToAllLayouts("EVENT_UPDATE_PEDIDOS")
 			})
-		document.SetEvent("ChangeStatus", func(this *liveview.Layout, message interface{}) {
+		document.SetEvent("ChangeStatus", func(this *liveview.Layout, ctx context.Context, message interface{}) {
 			var data map[string]string
 			json.Unmarshal([]byte(message.(string)), &data)
 			id := data["id"]
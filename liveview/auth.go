@@ -0,0 +1,35 @@
+package liveview
+
+import "context"
+
+// authorizer is implemented by ComponentDriver, the events of components that it rejects are dropped
+type authorizer interface {
+	authorize(sessionInfo interface{}) bool
+}
+
+// WithAuth set the check of events of component, sessionInfo is the value returned by PageControl.AuthHandler.
+// If it returns false the event is dropped and the browser is redirected to PageControl.AuthRedirectURL
+func (cw *ComponentDriver[T]) WithAuth(authHandler func(componentID string, sessionInfo interface{}) bool) *ComponentDriver[T] {
+	cw.authHandler = authHandler
+	return cw
+}
+
+// sessionExecutor is implemented by ComponentDriver, the ctx of event has the sessionInfo of the connection
+type sessionExecutor interface {
+	executeEvent(parent context.Context, name string, data interface{})
+}
+
+type sessionInfoKey struct{}
+
+// SessionInfo return the value returned by PageControl.AuthHandler for the connection of the event, ctx is the ctx of
+// event (the methods with signature (ctx context.Context, data interface{}) and SetEvent receive it)
+func SessionInfo(ctx context.Context) interface{} {
+	return ctx.Value(sessionInfoKey{})
+}
+
+func (cw *ComponentDriver[T]) authorize(sessionInfo interface{}) bool {
+	if cw.authHandler == nil {
+		return true
+	}
+	return cw.authHandler(cw.IdComponent, sessionInfo)
+}
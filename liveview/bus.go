@@ -0,0 +1,162 @@
+package liveview
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BusConfig it is the configuration of CommunicationBus
+type BusConfig struct {
+	// MaxQueueDepth is the max of payloads pending by subscriber
+	MaxQueueDepth int
+	// DeliveryTimeout is the max time that Publish wait for a subscriber with the queue full, after it the payload is dropped
+	DeliveryTimeout time.Duration
+}
+
+// CommunicationBus is a pub/sub between components, the topics are strings separated by "." and support wildcards "kanban.*"
+type CommunicationBus struct {
+	config BusConfig
+	mu     sync.RWMutex
+	nextID uint64
+	subs   map[uint64]*busSubscriber
+}
+
+type busSubscriber struct {
+	topic string
+	queue chan interface{}
+	done  chan struct{}
+}
+
+var bus = NewCommunicationBus(BusConfig{MaxQueueDepth: 100, DeliveryTimeout: time.Second})
+
+// NewCommunicationBus create a bus, for use the same bus in all page use Bus()
+func NewCommunicationBus(config BusConfig) *CommunicationBus {
+	if config.MaxQueueDepth <= 0 {
+		config.MaxQueueDepth = 100
+	}
+	if config.DeliveryTimeout <= 0 {
+		config.DeliveryTimeout = time.Second
+	}
+	return &CommunicationBus{config: config, subs: make(map[uint64]*busSubscriber)}
+}
+
+// Bus return the global CommunicationBus
+func Bus() *CommunicationBus {
+	return bus
+}
+
+// Bus return the global CommunicationBus
+func (cw *ComponentDriver[T]) Bus() *CommunicationBus {
+	return bus
+}
+
+// Publish send payload to all subscribers of topic, the subscribers with the queue full are waited at the same time, so
+// Publish waits at most DeliveryTimeout
+func (b *CommunicationBus) Publish(topic string, payload interface{}) {
+	b.mu.RLock()
+	subs := make([]*busSubscriber, 0)
+	for _, s := range b.subs {
+		if MatchTopic(s.topic, topic) {
+			subs = append(subs, s)
+		}
+	}
+	b.mu.RUnlock()
+	expired := make(chan struct{})
+	timer := time.AfterFunc(b.config.DeliveryTimeout, func() { close(expired) })
+	defer timer.Stop()
+	var wg sync.WaitGroup
+	for _, s := range subs {
+		select {
+		case s.queue <- payload:
+			continue
+		default:
+		}
+		wg.Add(1)
+		go func(s *busSubscriber) {
+			defer wg.Done()
+			select {
+			case s.queue <- payload:
+			case <-s.done:
+			case <-expired:
+				fmt.Println("CommunicationBus: payload dropped for", s.topic, "topic", topic)
+			}
+		}(s)
+	}
+	wg.Wait()
+}
+
+// HasSubscribers return true if some subscriber match topic
+func (b *CommunicationBus) HasSubscribers(topic string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, s := range b.subs {
+		if MatchTopic(s.topic, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe register fx for the topic, the payloads are delivered in order in other goroutine. Use cancel for unsubscribe.
+func (b *CommunicationBus) Subscribe(topic string, fx func(payload interface{})) (cancel func()) {
+	s := &busSubscriber{
+		topic: topic,
+		queue: make(chan interface{}, b.config.MaxQueueDepth),
+		done:  make(chan struct{}),
+	}
+	b.mu.Lock()
+	b.nextID++
+	id := b.nextID
+	b.subs[id] = s
+	b.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case payload := <-s.queue:
+				func() {
+					defer HandleReover()
+					fx(payload)
+				}()
+			case <-s.done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+			close(s.done)
+		})
+	}
+}
+
+// MatchTopic return true if topic match with pattern, "*" match one segment and if it is the last segment match the rest
+func MatchTopic(pattern string, topic string) bool {
+	if pattern == "*" || pattern == topic {
+		return true
+	}
+	p := strings.Split(pattern, ".")
+	t := strings.Split(topic, ".")
+	for i, segment := range p {
+		if i >= len(t) {
+			return false
+		}
+		if segment == "*" {
+			if i == len(p)-1 {
+				return true
+			}
+			continue
+		}
+		if segment != t[i] {
+			return false
+		}
+	}
+	return len(p) == len(t)
+}
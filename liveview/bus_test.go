@@ -0,0 +1,72 @@
+package liveview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		want    bool
+	}{
+		{"kanban.move", "kanban.move", true},
+		{"kanban.move", "kanban.add", false},
+		{"*", "kanban.move", true},
+		{"*", "", true},
+		{"kanban.*", "kanban.move", true},
+		{"kanban.*", "kanban.move.card", true},
+		{"kanban.*", "kanban", false},
+		{"kanban.*", "chat.move", false},
+		{"*.move", "kanban.move", true},
+		{"*.move", "kanban.move.card", false},
+		{"*.move", "kanban.add", false},
+		{"a.*.c", "a.b.c", true},
+		{"a.*.c", "a.b.d", false},
+		{"a.*.c", "a.b", false},
+		{"a.*.c", "a.b.c.d", false},
+		{"a.b", "a.b.c", false},
+		{"a.b.c", "a.b", false},
+		{"", "", true},
+		{"", "a", false},
+	}
+	for _, tt := range tests {
+		if got := MatchTopic(tt.pattern, tt.topic); got != tt.want {
+			t.Errorf("MatchTopic(%q, %q) = %t, want %t", tt.pattern, tt.topic, got, tt.want)
+		}
+	}
+}
+
+func TestPublishFullQueues(t *testing.T) {
+	b := NewCommunicationBus(BusConfig{MaxQueueDepth: 1, DeliveryTimeout: 50 * time.Millisecond})
+	block := make(chan struct{})
+	defer close(block)
+	for i := 0; i < 5; i++ {
+		cancel := b.Subscribe("topic", func(payload interface{}) { <-block })
+		defer cancel()
+	}
+	received := make(chan interface{}, 10)
+	cancel := b.Subscribe("topic", func(payload interface{}) { received <- payload })
+	defer cancel()
+	// the first payload is taken by the subscribers and the second fills their queues
+	b.Publish("topic", 1)
+	time.Sleep(10 * time.Millisecond)
+	b.Publish("topic", 2)
+	start := time.Now()
+	b.Publish("topic", 3)
+	// the full subscribers are waited at the same time, not one after other
+	if elapsed := time.Since(start); elapsed > 4*50*time.Millisecond {
+		t.Errorf("Publish took %s with 5 full subscribers", elapsed)
+	}
+	for _, want := range []int{1, 2, 3} {
+		select {
+		case got := <-received:
+			if got != want {
+				t.Errorf("received %v, want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("payload %d was not received", want)
+		}
+	}
+}
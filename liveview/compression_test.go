@@ -0,0 +1,98 @@
+package liveview
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// countConn count the bytes read from the network
+type countConn struct {
+	net.Conn
+	read *int64
+}
+
+func (c countConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	atomic.AddInt64(c.read, int64(n))
+	return n, err
+}
+
+// flowPayload return a fill of ~20KB like the html of a flow diagram
+func flowPayload() map[string]interface{} {
+	sb := &strings.Builder{}
+	sb.WriteString(`<div class="flow-canvas"><svg width="1200" height="800">`)
+	for i := 0; sb.Len() < 20*1024; i++ {
+		fmt.Fprintf(sb, `<g class="flow-box" id="box_%d" transform="translate(%d,%d)" onclick="send_event('flow','SelectBox','box_%d')">`+
+			`<rect width="120" height="60" rx="6" fill="#e3f2fd" stroke="#1976d2"/><text x="60" y="34" text-anchor="middle">Step %d</text></g>`,
+			i, (i%8)*140, (i/8)*90, i, i)
+		if i > 0 {
+			fmt.Fprintf(sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999" marker-end="url(#arrow)"/>`, ((i-1)%8)*140+120, ((i-1)/8)*90+30, (i%8)*140, (i/8)*90+30)
+		}
+	}
+	sb.WriteString(`</svg></div>`)
+	return map[string]interface{}{"type": "fill", "id": "mount_span_flow", "value": sb.String()}
+}
+
+func benchmarkFill(b *testing.B, compression bool) {
+	pc := &PageControl{EnableCompression: compression, CompressionThreshold: 1024}
+	data := flowPayload()
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upgrader := websocket.Upgrader{EnableCompression: compression}
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		for i := 0; i < b.N; i++ {
+			if err := pc.writeMessage(ws, data); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+		<-done
+	}))
+	defer server.Close()
+
+	var read int64
+	dialer := websocket.Dialer{
+		EnableCompression: compression,
+		NetDial: func(network, addr string) (net.Conn, error) {
+			conn, err := net.Dial(network, addr)
+			return countConn{Conn: conn, read: &read}, err
+		},
+	}
+	ws, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer ws.Close()
+	defer close(done)
+
+	b.SetBytes(int64(len(data["value"].(string))))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := ws.ReadMessage(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(atomic.LoadInt64(&read))/float64(b.N), "wire-B/op")
+}
+
+// BenchmarkFillUncompressed and BenchmarkFillCompressed send a fill of ~20KB from PageControl.writeMessage to a
+// websocket client, wire-B/op is the size on the network
+func BenchmarkFillUncompressed(b *testing.B) {
+	benchmarkFill(b, false)
+}
+
+func BenchmarkFillCompressed(b *testing.B) {
+	benchmarkFill(b, true)
+}
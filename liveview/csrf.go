@@ -0,0 +1,110 @@
+package liveview
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+// csrfSessionCookie is the cookie with the session id that the CSRF token and the replay session are bound to
+const csrfSessionCookie = "liveview_session"
+
+// CSRFTokenMaxAge is the max age of token of websocket
+var CSRFTokenMaxAge = time.Hour
+
+// CSRFHandshakeTimeout is the time that the websocket waits the message with the token
+var CSRFHandshakeTimeout = 10 * time.Second
+
+var (
+	errCSRFInvalid = errors.New("invalid csrf token")
+	errCSRFExpired = errors.New("expired csrf token")
+)
+
+// NewCSRFToken return token "session:timestamp:signature" signed with HMAC-SHA256
+func NewCSRFToken(secret string, sessionID string, now time.Time) string {
+	payload := sessionID + ":" + strconv.FormatInt(now.Unix(), 10)
+	return payload + ":" + signCSRF(secret, payload)
+}
+
+// ValidateCSRFToken return true if the token was signed with secret for sessionID and it is not expired
+func ValidateCSRFToken(secret string, token string, sessionID string, now time.Time) bool {
+	return checkCSRFToken(secret, token, sessionID, now) == nil
+}
+
+func checkCSRFToken(secret string, token string, sessionID string, now time.Time) error {
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 || sessionID == "" || parts[0] != sessionID {
+		return errCSRFInvalid
+	}
+	payload := parts[0] + ":" + parts[1]
+	if !hmac.Equal([]byte(signCSRF(secret, payload)), []byte(parts[2])) {
+		return errCSRFInvalid
+	}
+	ts, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return errCSRFInvalid
+	}
+	age := now.Sub(time.Unix(ts, 0))
+	if age < 0 {
+		return errCSRFInvalid
+	}
+	if age > CSRFTokenMaxAge {
+		return errCSRFExpired
+	}
+	return nil
+}
+
+// validateCSRF read the first message of ws, it must be {"type": "csrf", "token": token}. When the token is expired
+// (the page was open more than CSRFTokenMaxAge) the page is reloaded for get a new token, without it the reconnect
+// would fail forever
+func (pc *PageControl) validateCSRF(ws *websocket.Conn, sessionID string) bool {
+	ws.SetReadDeadline(time.Now().Add(CSRFHandshakeTimeout))
+	_, msg, err := ws.ReadMessage()
+	if err != nil {
+		return false
+	}
+	ws.SetReadDeadline(time.Time{})
+	var data map[string]interface{}
+	json.Unmarshal(msg, &data)
+	token, _ := data["token"].(string)
+	if data["type"] != "csrf" {
+		return false
+	}
+	switch checkCSRFToken(pc.CSRFSecret, token, sessionID, time.Now()) {
+	case nil:
+		return true
+	case errCSRFExpired:
+		pc.writeMessage(ws, map[string]interface{}{"type": "script", "value": "window.location.reload()"})
+	}
+	return false
+}
+
+// csrfSession return the session id of cookie, if it does not exist and create is true it sets a new one
+func csrfSession(c echo.Context, create bool) string {
+	if cookie, err := c.Request().Cookie(csrfSessionCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	if !create {
+		return ""
+	}
+	sessionID := uuid.NewString()
+	http.SetCookie(c.Response(), &http.Cookie{Name: csrfSessionCookie, Value: sessionID, Path: "/", HttpOnly: true,
+		Secure: c.Request().TLS != nil, SameSite: http.SameSiteLaxMode})
+	return sessionID
+}
+
+func signCSRF(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,107 @@
+package liveview
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestValidateCSRFToken(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	token := NewCSRFToken("secret", "session1", now)
+	parts := strings.Split(token, ":")
+	tampered := []byte(parts[2])
+	tampered[0] ^= 1
+	tests := []struct {
+		name    string
+		secret  string
+		token   string
+		session string
+		now     time.Time
+		want    error
+	}{
+		{"valid", "secret", token, "session1", now, nil},
+		{"valid at max age", "secret", token, "session1", now.Add(CSRFTokenMaxAge), nil},
+		{"expired", "secret", token, "session1", now.Add(CSRFTokenMaxAge + time.Second), errCSRFExpired},
+		{"issued in the future", "secret", token, "session1", now.Add(-time.Second), errCSRFInvalid},
+		{"wrong session", "secret", token, "session2", now, errCSRFInvalid},
+		{"empty session", "secret", token, "", now, errCSRFInvalid},
+		{"wrong secret", "other", token, "session1", now, errCSRFInvalid},
+		{"tampered mac", "secret", parts[0] + ":" + parts[1] + ":" + string(tampered), "session1", now, errCSRFInvalid},
+		{"tampered timestamp", "secret", parts[0] + ":" + "1700000100" + ":" + parts[2], "session1", now.Add(time.Minute), errCSRFInvalid},
+		{"other session with its mac", "secret", "session2:" + parts[1] + ":" + parts[2], "session2", now, errCSRFInvalid},
+		{"malformed", "secret", "session1:" + parts[1], "session1", now, errCSRFInvalid},
+		{"empty", "secret", "", "session1", now, errCSRFInvalid},
+	}
+	for _, tt := range tests {
+		if err := checkCSRFToken(tt.secret, tt.token, tt.session, tt.now); err != tt.want {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+		if got := ValidateCSRFToken(tt.secret, tt.token, tt.session, tt.now); got != (tt.want == nil) {
+			t.Errorf("%s: ValidateCSRFToken = %t", tt.name, got)
+		}
+	}
+}
+
+// csrfHandshake send msg as first message to validateCSRF and return its result and the messages received by the client
+func csrfHandshake(t *testing.T, msg string) (bool, []string) {
+	pc := &PageControl{CSRFSecret: "secret"}
+	result := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		result <- pc.validateCSRF(ws, "session1")
+	}))
+	defer server.Close()
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if err := ws.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatal(err)
+	}
+	ok := <-result
+	var received []string
+	ws.SetReadDeadline(time.Now().Add(time.Second))
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			return ok, received
+		}
+		received = append(received, string(data))
+	}
+}
+
+func TestValidateCSRFHandshake(t *testing.T) {
+	valid := NewCSRFToken("secret", "session1", time.Now())
+	expired := NewCSRFToken("secret", "session1", time.Now().Add(-CSRFTokenMaxAge-time.Minute))
+	tests := []struct {
+		name   string
+		msg    string
+		ok     bool
+		reload bool
+	}{
+		{"valid", `{"type":"csrf","token":"` + valid + `"}`, true, false},
+		{"other message", `{"type":"data","id":"x","event":"Click"}`, false, false},
+		{"wrong session", `{"type":"csrf","token":"` + NewCSRFToken("secret", "session2", time.Now()) + `"}`, false, false},
+		{"expired reloads the page", `{"type":"csrf","token":"` + expired + `"}`, false, true},
+	}
+	for _, tt := range tests {
+		ok, received := csrfHandshake(t, tt.msg)
+		if ok != tt.ok {
+			t.Errorf("%s: validateCSRF = %t, want %t", tt.name, ok, tt.ok)
+		}
+		reload := len(received) == 1 && strings.Contains(received[0], "window.location.reload()")
+		if reload != tt.reload || (!tt.reload && len(received) > 0) {
+			t.Errorf("%s: received %q", tt.name, received)
+		}
+	}
+}
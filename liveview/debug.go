@@ -0,0 +1,94 @@
+package liveview
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DebugCommit is the payload of topic "liveview.debug.commit.<connection>.<id>" (see DebugTopic)
+type DebugCommit struct {
+	ComponentID string
+	Duration    time.Duration
+	Bytes       int
+	Time        time.Time
+}
+
+// DebugEvent is the payload of topic "liveview.debug.event.<connection>.<id>" (see DebugTopic)
+type DebugEvent struct {
+	ComponentID string
+	Name        string
+	Data        interface{}
+	Time        time.Time
+}
+
+type debugMessage struct {
+	topic   string
+	payload interface{}
+}
+
+var (
+	debugEvents atomic.Bool
+	// debugQueue keep the order of debug messages, they are published by one goroutine so Commit and the events do not
+	// wait for the subscribers (the messages are dropped when it is full)
+	debugQueue     = make(chan debugMessage, 1000)
+	debugQueueOnce sync.Once
+	// NewInspector create the inspector of component targetID mounted in the element container, it is used by PageControl.Debug
+	// and it is set by the package components
+	NewInspector func(targetID string, container string) Component
+)
+
+// EnableDebugEvents publish the commits and events of all components in the bus with the topics liveview.debug.*
+func EnableDebugEvents() {
+	debugQueueOnce.Do(func() {
+		go func() {
+			for m := range debugQueue {
+				bus.Publish(m.topic, m.payload)
+			}
+		}()
+	})
+	debugEvents.Store(true)
+}
+
+// DebugTopic return the topic of debug messages kind ("commit" or "event") of componentID in the connection of cw, the
+// ids of components repeat in each connection so the topic has the connection
+func (cw *ComponentDriver[T]) DebugTopic(kind string, componentID string) string {
+	return fmt.Sprintf("liveview.debug.%s.%p.%s", kind, cw.channel, componentID)
+}
+
+// publishDebug queue payload if debug is enabled and topic has subscribers
+func publishDebug(topic string, payload func() interface{}) {
+	if !debugEvents.Load() || !bus.HasSubscribers(topic) {
+		return
+	}
+	select {
+	case debugQueue <- debugMessage{topic: topic, payload: payload()}:
+	default:
+		fmt.Println("liveview: debug message dropped for topic", topic)
+	}
+}
+
+func (cw *ComponentDriver[T]) publishDebugCommit(start time.Time, bytes int) {
+	id := cw.GetIDComponet()
+	publishDebug(cw.DebugTopic("commit", id), func() interface{} {
+		return DebugCommit{ComponentID: id, Duration: time.Since(start), Bytes: bytes, Time: time.Now()}
+	})
+}
+
+func (cw *ComponentDriver[T]) publishDebugEvent(name string, data interface{}) {
+	id := cw.GetIDComponet()
+	publishDebug(cw.DebugTopic("event", id), func() interface{} {
+		return DebugEvent{ComponentID: id, Name: name, Data: data, Time: time.Now()}
+	})
+}
+
+// FindDriver return the driver of component id in the page or nil
+func (cw *ComponentDriver[T]) FindDriver(id string) LiveDriver {
+	if cw.DriversPage == nil {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return (*cw.DriversPage)[id]
+}
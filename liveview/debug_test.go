@@ -0,0 +1,69 @@
+package liveview
+
+import (
+	"testing"
+	"time"
+)
+
+type debugTestComponent struct {
+	*ComponentDriver[*debugTestComponent]
+}
+
+func (t *debugTestComponent) GetDriver() LiveDriver { return t }
+func (t *debugTestComponent) Start()                {}
+func (t *debugTestComponent) GetTemplate() string   { return `<div id="{{.IdComponent}}"></div>` }
+
+// newDebugTestDriver return the driver of component id in a new connection
+func newDebugTestDriver(id string) *ComponentDriver[*debugTestComponent] {
+	driver := NewDriver(id, &debugTestComponent{})
+	driver.channel = make(chan map[string]interface{})
+	return driver
+}
+
+func TestDebugTopicPerConnection(t *testing.T) {
+	EnableDebugEvents()
+	first, second := newDebugTestDriver("counter"), newDebugTestDriver("counter")
+	if first.DebugTopic("event", "counter") == second.DebugTopic("event", "counter") {
+		t.Fatal("the topics of two connections are equal")
+	}
+	events := make(chan DebugEvent, 10)
+	cancel := Bus().Subscribe(first.DebugTopic("event", "counter"), func(payload interface{}) {
+		events <- payload.(DebugEvent)
+	})
+	defer cancel()
+
+	second.publishDebugEvent("Increment", "second")
+	first.publishDebugEvent("Increment", "first")
+	select {
+	case e := <-events:
+		if e.Data != "first" {
+			t.Errorf("received the event of other connection: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the event was not received")
+	}
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event %+v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDebugPublishDoesNotBlock(t *testing.T) {
+	EnableDebugEvents()
+	driver := newDebugTestDriver("slow")
+	release := make(chan struct{})
+	cancel := Bus().Subscribe(driver.DebugTopic("commit", "slow"), func(payload interface{}) {
+		<-release
+	})
+	defer cancel()
+	defer close(release)
+
+	start := time.Now()
+	for i := 0; i < 5000; i++ {
+		driver.publishDebugCommit(time.Now(), 10)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("publish with a blocked subscriber took %s", elapsed)
+	}
+}
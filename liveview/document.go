@@ -0,0 +1,58 @@
+package liveview
+
+import "encoding/json"
+
+// DocumentDriver it is implemented by ComponentDriver, use with type assertion over LiveDriver
+type DocumentDriver interface {
+	SetTitle(title string)
+	SetFavicon(url string)
+	SetMetaTag(name, content string)
+	RemoveMetaTag(name string)
+}
+
+// jsString return s as literal string of javascript
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// SetTitle execute document.title = $title
+func (cw *ComponentDriver[T]) SetTitle(title string) {
+	cw.EvalScript("document.title = " + jsString(title) + ";")
+}
+
+// SetFavicon change href of link[rel=icon], the link is created if not exists
+func (cw *ComponentDriver[T]) SetFavicon(url string) {
+	cw.EvalScript(`(function(){
+		var link = document.querySelector("link[rel~='icon']");
+		if (!link) {
+			link = document.createElement("link");
+			link.rel = "icon";
+			document.head.appendChild(link);
+		}
+		link.href = ` + jsString(url) + `;
+	})();`)
+}
+
+// SetMetaTag set content of meta[name=$name], the meta is created if not exists
+func (cw *ComponentDriver[T]) SetMetaTag(name, content string) {
+	cw.EvalScript(`(function(){
+		var meta = document.querySelector("meta[name=" + JSON.stringify(` + jsString(name) + `) + "]");
+		if (!meta) {
+			meta = document.createElement("meta");
+			meta.name = ` + jsString(name) + `;
+			document.head.appendChild(meta);
+		}
+		meta.content = ` + jsString(content) + `;
+	})();`)
+}
+
+// RemoveMetaTag remove meta[name=$name]
+func (cw *ComponentDriver[T]) RemoveMetaTag(name string) {
+	cw.EvalScript(`(function(){
+		var meta = document.querySelector("meta[name=" + JSON.stringify(` + jsString(name) + `) + "]");
+		if (meta) {
+			meta.remove();
+		}
+	})();`)
+}
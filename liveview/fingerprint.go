@@ -0,0 +1,71 @@
+package liveview
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fingerprintAssets hash with SHA-256 the files of dir assets, the manifest maps "/assets/name" to "/assets/name?v=hash"
+func (pc *PageControl) fingerprintAssets() {
+	manifest := make(map[string]string)
+	filepath.Walk("assets", func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer file.Close()
+		hash := sha256.New()
+		if _, err := io.Copy(hash, file); err != nil {
+			return nil
+		}
+		url := "/" + filepath.ToSlash(path)
+		manifest[url] = url + "?v=" + hex.EncodeToString(hash.Sum(nil))[:12]
+		return nil
+	})
+	pc.muManifest.Lock()
+	pc.manifest = manifest
+	pc.muManifest.Unlock()
+	fmt.Println("Asset fingerprinting:", len(manifest), "assets")
+}
+
+// AssetManifest return the map of asset path to url with hash, it is empty without EnableAssetFingerprinting
+func (pc *PageControl) AssetManifest() map[string]string {
+	pc.muManifest.Lock()
+	defer pc.muManifest.Unlock()
+	manifest := make(map[string]string, len(pc.manifest))
+	for k, v := range pc.manifest {
+		manifest[k] = v
+	}
+	return manifest
+}
+
+// assetURL return the url with hash of path or path
+func (pc *PageControl) assetURL(path string) string {
+	pc.muManifest.Lock()
+	defer pc.muManifest.Unlock()
+	if url, ok := pc.manifest[path]; ok {
+		return url
+	}
+	return path
+}
+
+// rewriteAssetURLs add the hash to the urls of assets between quotes ("assets/json.wasm" or "/assets/json.wasm")
+func (pc *PageControl) rewriteAssetURLs(html string) string {
+	for path, url := range pc.AssetManifest() {
+		query := url[len(path):]
+		relative := strings.TrimPrefix(path, "/")
+		for _, quote := range []string{`"`, `'`} {
+			html = strings.ReplaceAll(html, quote+relative+quote, quote+relative+query+quote)
+			html = strings.ReplaceAll(html, quote+path+quote, quote+path+query+quote)
+		}
+	}
+	return html
+}
@@ -10,5 +10,10 @@ var (
 			return "<span id='mount_span_" + id + "'></span>"
 		},
 		"eqInt": func(value1, value2 int) bool { return value1 == value2 },
+		// t and tp use the default locale, in Commit they are replaced by the locale of component
+		"t": func(key string, args ...interface{}) string {
+			return translate(GetLocale(), key, args...)
+		},
+		"tp": func(key string, count int) string { return tPlural(GetLocale(), key, count) },
 	}
 )
@@ -0,0 +1,137 @@
+package liveview
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	translations   = make(map[string]map[string]string)
+	muTranslations sync.RWMutex
+	currentLocale  = "en"
+	// FallbackLocale is the last locale of the fallback chain (default "en")
+	FallbackLocale = "en"
+)
+
+// normalizeLocale return the locale with "_" and lower language, "es-mx" is "es_MX"
+func normalizeLocale(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "-", "_")
+	parts := strings.SplitN(locale, "_", 2)
+	if len(parts) == 2 {
+		return strings.ToLower(parts[0]) + "_" + strings.ToUpper(parts[1])
+	}
+	return strings.ToLower(locale)
+}
+
+// RegisterTranslations add the translations of locale, the keys registered before are replaced
+func RegisterTranslations(locale string, values map[string]string) {
+	muTranslations.Lock()
+	defer muTranslations.Unlock()
+	locale = normalizeLocale(locale)
+	if translations[locale] == nil {
+		translations[locale] = make(map[string]string)
+	}
+	for k, v := range values {
+		translations[locale][k] = v
+	}
+}
+
+// SetLocale change the default locale, it is used by the components without ComponentDriver.Locale
+func SetLocale(locale string) {
+	muTranslations.Lock()
+	defer muTranslations.Unlock()
+	currentLocale = normalizeLocale(locale)
+}
+
+// GetLocale return the default locale
+func GetLocale() string {
+	muTranslations.RLock()
+	defer muTranslations.RUnlock()
+	return currentLocale
+}
+
+// lookup search key in the fallback chain of locale (es_MX -> es -> FallbackLocale)
+func lookup(locale string, key string) (string, bool) {
+	muTranslations.RLock()
+	defer muTranslations.RUnlock()
+	locale = normalizeLocale(locale)
+	chain := []string{locale}
+	if i := strings.Index(locale, "_"); i > 0 {
+		chain = append(chain, locale[:i])
+	}
+	chain = append(chain, normalizeLocale(FallbackLocale))
+	for _, l := range chain {
+		if value, ok := translations[l][key]; ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// translate return the translation of key in locale or the key if it is not found, the args are formatted with fmt.Sprintf
+func translate(locale string, key string, args ...interface{}) string {
+	value, ok := lookup(locale, key)
+	if !ok {
+		value = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(value, args...)
+	}
+	return value
+}
+
+// TFunc return the function of translation of locale for use in Go code
+func TFunc(locale string) func(key string) string {
+	return func(key string) string {
+		return translate(locale, key)
+	}
+}
+
+// T return the translation of key in the default locale
+func T(key string) string {
+	return translate(GetLocale(), key)
+}
+
+// TFormat return the translation of key in the default locale formatted with args, example "Hello %s, you have %d messages"
+func TFormat(key string, args ...interface{}) string {
+	return translate(GetLocale(), key, args...)
+}
+
+// TPlural return the translation of "key.zero" (if count is 0 and exists), "key.one" (if count is 1 and exists) or
+// "key.other" in the default locale, the %d of message is replaced by count
+func TPlural(key string, count int) string {
+	return tPlural(GetLocale(), key, count)
+}
+
+func tPlural(locale string, key string, count int) string {
+	forms := []string{key + ".other"}
+	switch count {
+	case 0:
+		forms = []string{key + ".zero", key + ".other"}
+	case 1:
+		forms = []string{key + ".one", key + ".other"}
+	}
+	forms = append(forms, key)
+	for _, form := range forms {
+		if value, ok := lookup(locale, form); ok {
+			if strings.Contains(value, "%d") {
+				return fmt.Sprintf(value, count)
+			}
+			return value
+		}
+	}
+	return key
+}
+
+// templateFuncsLocale return the functions "t" and "tp" of templates for locale
+func templateFuncsLocale(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"t": func(key string, args ...interface{}) string {
+			return translate(locale, key, args...)
+		},
+		"tp": func(key string, count int) string {
+			return tPlural(locale, key, count)
+		},
+	}
+}
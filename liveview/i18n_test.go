@@ -0,0 +1,80 @@
+package liveview
+
+import "testing"
+
+func setupTranslations(t *testing.T) {
+	muTranslations.Lock()
+	translations = make(map[string]map[string]string)
+	muTranslations.Unlock()
+	t.Cleanup(func() {
+		muTranslations.Lock()
+		translations = make(map[string]map[string]string)
+		muTranslations.Unlock()
+	})
+	RegisterTranslations("en", map[string]string{
+		"hello":         "Hello",
+		"bye":           "Bye",
+		"car":           "Car",
+		"items.one":     "%d item",
+		"items.other":   "%d items",
+		"messages.zero": "No messages",
+		"files.other":   "%d files",
+	})
+	RegisterTranslations("es", map[string]string{
+		"hello":     "Hola",
+		"car":       "Coche",
+		"items.one": "%d elemento",
+	})
+	RegisterTranslations("es-mx", map[string]string{
+		"car": "Carro",
+	})
+}
+
+func TestTranslateFallbackChain(t *testing.T) {
+	setupTranslations(t)
+	tests := []struct {
+		locale string
+		key    string
+		want   string
+	}{
+		{"es_MX", "car", "Carro"},
+		{"es_MX", "hello", "Hola"},
+		{"es_MX", "bye", "Bye"},
+		{"es_MX", "missing", "missing"},
+		{"es", "car", "Coche"},
+		{"es", "bye", "Bye"},
+		{"es-mx", "car", "Carro"},
+		{"fr", "hello", "Hello"},
+		{"fr", "missing", "missing"},
+	}
+	for _, tt := range tests {
+		if got := TFunc(tt.locale)(tt.key); got != tt.want {
+			t.Errorf("TFunc(%q)(%q) = %q, want %q", tt.locale, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestTranslatePlural(t *testing.T) {
+	setupTranslations(t)
+	tests := []struct {
+		locale string
+		key    string
+		count  int
+		want   string
+	}{
+		{"en", "items", 1, "1 item"},
+		{"en", "items", 3, "3 items"},
+		{"en", "items", 0, "0 items"},
+		{"es_MX", "items", 1, "1 elemento"},
+		{"es_MX", "items", 2, "2 items"},
+		{"en", "messages", 0, "No messages"},
+		{"en", "files", 1, "1 files"},
+		{"es", "files", 1, "1 files"},
+		{"en", "missing", 1, "missing"},
+	}
+	for _, tt := range tests {
+		if got := tPlural(tt.locale, tt.key, tt.count); got != tt.want {
+			t.Errorf("tPlural(%q, %q, %d) = %q, want %q", tt.locale, tt.key, tt.count, got, tt.want)
+		}
+	}
+}
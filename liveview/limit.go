@@ -0,0 +1,50 @@
+package liveview
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// acquireConnection return true if the websocket can be opened. When MaxConnections are open it waits for a free slot
+// if there are less than ConnectionQueueDepth waiting, else it returns false
+func (pc *PageControl) acquireConnection(ctx context.Context) bool {
+	if pc.MaxConnections <= 0 {
+		return true
+	}
+	pc.onceSlots.Do(func() {
+		pc.slots = make(chan struct{}, pc.MaxConnections)
+	})
+	select {
+	case pc.slots <- struct{}{}:
+		return true
+	default:
+	}
+	if pc.queued.Add(1) > int64(pc.ConnectionQueueDepth) {
+		pc.queued.Add(-1)
+		return false
+	}
+	defer pc.queued.Add(-1)
+	select {
+	case pc.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (pc *PageControl) releaseConnection() {
+	if pc.MaxConnections > 0 {
+		<-pc.slots
+	}
+}
+
+// rejectConnection answer with OnConnectionLimitReached or 503 with JSON error
+func (pc *PageControl) rejectConnection(c echo.Context) error {
+	metricConnectionRejected()
+	if pc.OnConnectionLimitReached != nil {
+		return pc.OnConnectionLimitReached(c)
+	}
+	return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "too many connections"})
+}
@@ -0,0 +1,162 @@
+package liveview
+
+import "reflect"
+
+var liveDriverType = reflect.TypeOf((*LiveDriver)(nil)).Elem()
+
+// updater is implemented by the components that decide if Commit renders, prev and next are maps with a copy of exported
+// fields of component (slices, maps and pointers are copied, the funcs and the other components are not part of the
+// state). A component with the method ShouldUpdate has the memo enabled, without set Memo
+type updater interface {
+	ShouldUpdate(prev, next interface{}) bool
+}
+
+// ForceUpdate execute Commit without check the memo, use it when the DOM was changed by other way (example EvalScript)
+func (cw *ComponentDriver[T]) ForceUpdate() {
+	cw.muState.Lock()
+	cw.forceUpdate = true
+	cw.muState.Unlock()
+	cw.Commit()
+}
+
+// invalidateState forget the state of last Commit, so the next Commit renders
+func (cw *ComponentDriver[T]) invalidateState() {
+	cw.muState.Lock()
+	cw.state = nil
+	cw.muState.Unlock()
+}
+
+// invalidateChildren is called when the element of component is filled, the mount spans of children are empty again so
+// their next Commit must render
+func (cw *ComponentDriver[T]) invalidateChildren() {
+	for _, child := range cw.componentsDrivers {
+		if c, ok := child.(interface{ invalidateState() }); ok {
+			c.invalidateState()
+		}
+	}
+}
+
+// needUpdate save the state of component and return if the Commit must render, it is always true without Memo or
+// ShouldUpdate because the template can use unexported fields, methods or globals
+func (cw *ComponentDriver[T]) needUpdate() bool {
+	su, ok := any(cw.Component).(updater)
+	if !ok && !cw.Memo {
+		return true
+	}
+	next := cw.snapshot()
+	cw.muState.Lock()
+	prev, force := cw.state, cw.forceUpdate
+	cw.state = next
+	cw.forceUpdate = false
+	cw.muState.Unlock()
+	if prev == nil || force {
+		return true
+	}
+	if ok {
+		return su.ShouldUpdate(prev, next)
+	}
+	return !reflect.DeepEqual(prev, next)
+}
+
+func (cw *ComponentDriver[T]) snapshot() map[string]interface{} {
+	cw.muState.Lock()
+	eventError := cw.EventError
+	cw.muState.Unlock()
+	state := map[string]interface{}{"Data": copyValue(reflect.ValueOf(cw.Data)), "EventError": eventError}
+	v := reflect.ValueOf(cw.Component)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return state
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return state
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous || !field.IsExported() || field.Type.Kind() == reflect.Func {
+			continue
+		}
+		state[field.Name] = copyValue(v.Field(i))
+	}
+	return state
+}
+
+// copyValue copy slices, maps, arrays, structs and pointers for detect changes in place
+func copyValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	c := copyReflect(v, make(map[uintptr]reflect.Value))
+	if !c.CanInterface() {
+		return nil
+	}
+	return c.Interface()
+}
+
+// copyReflect copy v, the funcs are zero (they are never equal) and the components are not copied (they have their own
+// Commit), seen has the copies of pointers for the cycles
+func copyReflect(v reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	if v.Type().Implements(liveDriverType) {
+		return v
+	}
+	switch v.Kind() {
+	case reflect.Func:
+		return reflect.Zero(v.Type())
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		if c, ok := seen[v.Pointer()]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		seen[v.Pointer()] = c
+		c.Elem().Set(copyReflect(v.Elem(), seen))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyReflect(v.Index(i), seen))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyReflect(v.Index(i), seen))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), copyReflect(iter.Value(), seen))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(copyReflect(v.Field(i), seen))
+			}
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(copyReflect(v.Elem(), seen))
+		return c
+	}
+	return v
+}
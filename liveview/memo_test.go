@@ -0,0 +1,145 @@
+package liveview
+
+import "testing"
+
+type memoTestComponent struct {
+	*ComponentDriver[*memoTestComponent]
+	Count  int
+	hidden int
+}
+
+func (t *memoTestComponent) GetDriver() LiveDriver { return t }
+func (t *memoTestComponent) Start()                {}
+func (t *memoTestComponent) GetTemplate() string {
+	return `<div id="{{.IdComponent}}">{{.Count}} {{.Hidden}}</div>`
+}
+func (t *memoTestComponent) Hidden() int { return t.hidden }
+
+// memoUpdater return update in ShouldUpdate
+type memoUpdater struct {
+	*ComponentDriver[*memoUpdater]
+	Count  int
+	update bool
+	calls  int
+}
+
+func (t *memoUpdater) GetDriver() LiveDriver { return t }
+func (t *memoUpdater) Start()                {}
+func (t *memoUpdater) GetTemplate() string   { return `<div id="{{.IdComponent}}">{{.Count}}</div>` }
+func (t *memoUpdater) ShouldUpdate(prev, next interface{}) bool {
+	t.calls++
+	return t.update
+}
+
+// newMemoTestDriver return the driver of c with a channel that keeps the messages
+func newMemoTestDriver[T Component](id string, c T) (*ComponentDriver[T], chan map[string]interface{}) {
+	channel := make(chan map[string]interface{}, 100)
+	driver := NewDriver(id, c)
+	driver.SetID("mount_span_" + id)
+	driver.channel = channel
+	return driver, channel
+}
+
+// fills return the count of fill messages of id in channel
+func fills(channel chan map[string]interface{}, id string) int {
+	count := 0
+	for {
+		select {
+		case msg := <-channel:
+			if msg["type"] == "fill" && msg["id"] == id {
+				count++
+			}
+		default:
+			return count
+		}
+	}
+}
+
+func TestCommitWithoutMemo(t *testing.T) {
+	c := &memoTestComponent{}
+	driver, channel := newMemoTestDriver("counter", c)
+	driver.Commit()
+	driver.Commit()
+	// the template uses an unexported field, without memo every Commit renders
+	c.hidden++
+	driver.Commit()
+	if got := fills(channel, driver.GetID()); got != 3 {
+		t.Errorf("Commit rendered %d times, want 3", got)
+	}
+}
+
+func TestCommitMemo(t *testing.T) {
+	c := &memoTestComponent{}
+	driver, channel := newMemoTestDriver("counter", c)
+	driver.Memo = true
+	driver.Commit()
+	driver.Commit()
+	if got := fills(channel, driver.GetID()); got != 1 {
+		t.Errorf("Commit without changes rendered %d times, want 1", got)
+	}
+	c.Count++
+	driver.Commit()
+	if got := fills(channel, driver.GetID()); got != 1 {
+		t.Errorf("Commit after a change rendered %d times, want 1", got)
+	}
+	driver.Data = map[string]int{"a": 1}
+	driver.Commit()
+	driver.Data.(map[string]int)["a"] = 2
+	driver.Commit()
+	if got := fills(channel, driver.GetID()); got != 2 {
+		t.Errorf("Commit after changes of Data rendered %d times, want 2", got)
+	}
+	driver.ForceUpdate()
+	if got := fills(channel, driver.GetID()); got != 1 {
+		t.Errorf("ForceUpdate rendered %d times, want 1", got)
+	}
+}
+
+func TestCommitShouldUpdate(t *testing.T) {
+	c := &memoUpdater{}
+	driver, channel := newMemoTestDriver("counter", c)
+	// ShouldUpdate enables the memo without Memo
+	driver.Commit()
+	c.Count++
+	driver.Commit()
+	if got := fills(channel, driver.GetID()); got != 1 {
+		t.Errorf("Commit with ShouldUpdate false rendered %d times, want 1", got)
+	}
+	if c.calls != 1 {
+		t.Errorf("ShouldUpdate was called %d times, want 1", c.calls)
+	}
+	c.update = true
+	driver.Commit()
+	if got := fills(channel, driver.GetID()); got != 1 {
+		t.Errorf("Commit with ShouldUpdate true rendered %d times, want 1", got)
+	}
+	c.update = false
+	driver.ForceUpdate()
+	if got := fills(channel, driver.GetID()); got != 1 {
+		t.Errorf("ForceUpdate rendered %d times, want 1", got)
+	}
+}
+
+func TestCommitInvalidateChildren(t *testing.T) {
+	parent, channel := newMemoTestDriver("parent", &memoTestComponent{})
+	child := NewDriver("child", &memoTestComponent{})
+	child.channel = channel
+	child.Memo = true
+	parent.Mount(child.Component)
+	child.Commit()
+	child.Commit()
+	if got := fills(channel, child.GetID()); got != 1 {
+		t.Errorf("Commit of child rendered %d times, want 1", got)
+	}
+	// the render of parent empties the mount span of child, so its next Commit renders without changes
+	parent.Commit()
+	child.Commit()
+	if got := fills(channel, child.GetID()); got != 1 {
+		t.Errorf("Commit of child after the render of parent rendered %d times, want 1", got)
+	}
+	parent.FillValue("<div></div>")
+	child.Commit()
+	if got := fills(channel, child.GetID()); got != 1 {
+		t.Errorf("Commit of child after FillValue of parent rendered %d times, want 1", got)
+	}
+}
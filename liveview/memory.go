@@ -0,0 +1,100 @@
+package liveview
+
+import (
+	"log"
+	"reflect"
+)
+
+// MemoryWarningThreshold log a warning when the MemoryEstimate of a component is bigger after Commit, 0 is disabled
+var MemoryWarningThreshold int64
+
+// MemoryEstimate return the approximate bytes held by the exported fields of component, it follows slices, maps, strings,
+// pointers and interfaces (each pointer is counted once)
+func (cw *ComponentDriver[T]) MemoryEstimate() int64 {
+	v := reflect.ValueOf(cw.Component)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return 0
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return 0
+	}
+	seen := make(map[uintptr]bool)
+	size := int64(v.Type().Size())
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		size += memoryReferenced(v.Field(i), seen)
+	}
+	return size
+}
+
+// memoryReferenced return the bytes referenced by v, without the size of v itself
+func memoryReferenced(v reflect.Value, seen map[uintptr]bool) int64 {
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Ptr:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		return int64(v.Type().Elem().Size()) + memoryReferenced(v.Elem(), seen)
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return int64(v.Elem().Type().Size()) + memoryReferenced(v.Elem(), seen)
+	case reflect.Slice:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		size := int64(v.Cap()) * int64(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			size += memoryReferenced(v.Index(i), seen)
+		}
+		return size
+	case reflect.Array:
+		size := int64(0)
+		for i := 0; i < v.Len(); i++ {
+			size += memoryReferenced(v.Index(i), seen)
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || seen[v.Pointer()] {
+			return 0
+		}
+		seen[v.Pointer()] = true
+		size := int64(0)
+		iter := v.MapRange()
+		for iter.Next() {
+			size += int64(v.Type().Key().Size()+v.Type().Elem().Size()) + memoryReferenced(iter.Key(), seen) + memoryReferenced(iter.Value(), seen)
+		}
+		return size
+	case reflect.Struct:
+		size := int64(0)
+		for i := 0; i < v.NumField(); i++ {
+			size += memoryReferenced(v.Field(i), seen)
+		}
+		return size
+	}
+	return 0
+}
+
+// observeMemory update the metric liveview_component_memory_bytes and log the components over MemoryWarningThreshold
+func (cw *ComponentDriver[T]) observeMemory() {
+	if !metricsEnabled && MemoryWarningThreshold <= 0 {
+		return
+	}
+	size := cw.MemoryEstimate()
+	metricComponentMemory(cw.componentType(), size)
+	if MemoryWarningThreshold > 0 && size > MemoryWarningThreshold {
+		log.Printf("Component %s uses about %d bytes (MemoryWarningThreshold %d)", cw.GetIDComponet(), size, MemoryWarningThreshold)
+	}
+}
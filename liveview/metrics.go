@@ -0,0 +1,182 @@
+//go:build metrics
+
+package liveview
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// metricsBuckets are the upper bounds (in seconds) used by all histograms
+var metricsBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+type labeledHistogram struct {
+	mu     sync.Mutex
+	values map[string]*histogram
+}
+
+func (h *labeledHistogram) observe(label string, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.values[label]
+	if !ok {
+		v = &histogram{counts: make([]uint64, len(metricsBuckets))}
+		h.values[label] = v
+	}
+	seconds := d.Seconds()
+	for i, le := range metricsBuckets {
+		if seconds <= le {
+			v.counts[i]++
+		}
+	}
+	v.count++
+	v.sum += seconds
+}
+
+func (h *labeledHistogram) write(sb *strings.Builder, name string, labelName string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	fmt.Fprintf(sb, "# TYPE %s histogram\n", name)
+	for _, label := range sortedKeys(h.values) {
+		v := h.values[label]
+		for i, le := range metricsBuckets {
+			fmt.Fprintf(sb, "%s_bucket{%s=%q,le=\"%g\"} %d\n", name, labelName, label, le, v.counts[i])
+		}
+		fmt.Fprintf(sb, "%s_bucket{%s=%q,le=\"+Inf\"} %d\n", name, labelName, label, v.count)
+		fmt.Fprintf(sb, "%s_sum{%s=%q} %g\n", name, labelName, label, v.sum)
+		fmt.Fprintf(sb, "%s_count{%s=%q} %d\n", name, labelName, label, v.count)
+	}
+}
+
+type labeledCounter struct {
+	mu     sync.Mutex
+	values map[string]*uint64
+}
+
+func (c *labeledCounter) add(label string, n uint64) {
+	c.mu.Lock()
+	v, ok := c.values[label]
+	if !ok {
+		v = new(uint64)
+		c.values[label] = v
+	}
+	c.mu.Unlock()
+	atomic.AddUint64(v, n)
+}
+
+func (c *labeledCounter) write(sb *strings.Builder, name string, labelName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fmt.Fprintf(sb, "# TYPE %s counter\n", name)
+	for _, label := range sortedKeys(c.values) {
+		fmt.Fprintf(sb, "%s{%s=%q} %d\n", name, labelName, label, atomic.LoadUint64(c.values[label]))
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+const metricsEnabled = true
+
+type labeledGauge struct {
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+func (g *labeledGauge) set(label string, value int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.values[label] = value
+}
+
+func (g *labeledGauge) write(sb *strings.Builder, name string, labelName string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fmt.Fprintf(sb, "# TYPE %s gauge\n", name)
+	for _, label := range sortedKeys(g.values) {
+		fmt.Fprintf(sb, "%s{%s=%q} %d\n", name, labelName, label, g.values[label])
+	}
+}
+
+var (
+	metricConnectionsActive    int64
+	metricCommitBytesTotal     uint64
+	metricCommitsSkipped       uint64
+	metricConnectionsReject    uint64
+	metricEventDuration        = &labeledHistogram{values: make(map[string]*histogram)}
+	metricCommitDuration       = &labeledHistogram{values: make(map[string]*histogram)}
+	metricErrorsTotal          = &labeledCounter{values: make(map[string]*uint64)}
+	metricComponentMemoryBytes = &labeledGauge{values: make(map[string]int64)}
+)
+
+func metricConnectionOpened() {
+	atomic.AddInt64(&metricConnectionsActive, 1)
+}
+
+func metricConnectionClosed() {
+	atomic.AddInt64(&metricConnectionsActive, -1)
+}
+
+func metricConnectionRejected() {
+	atomic.AddUint64(&metricConnectionsReject, 1)
+}
+
+// metricComponentMemory set the gauge of componentType with the last estimate of a component of this type
+func metricComponentMemory(componentType string, bytes int64) {
+	metricComponentMemoryBytes.set(componentType, bytes)
+}
+
+func metricObserveEvent(name string, start time.Time) {
+	metricEventDuration.observe(name, time.Since(start))
+}
+
+func metricObserveCommit(componentType string, start time.Time, bytes int) {
+	metricCommitDuration.observe(componentType, time.Since(start))
+	atomic.AddUint64(&metricCommitBytesTotal, uint64(bytes))
+}
+
+func metricCommitSkipped() {
+	atomic.AddUint64(&metricCommitsSkipped, 1)
+}
+
+func metricError(kind string) {
+	metricErrorsTotal.add(kind, 1)
+}
+
+// MetricsHandler return http.Handler with metrics in Prometheus text format
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sb := &strings.Builder{}
+		fmt.Fprintf(sb, "# TYPE liveview_websocket_connections_active gauge\n")
+		fmt.Fprintf(sb, "liveview_websocket_connections_active %d\n", atomic.LoadInt64(&metricConnectionsActive))
+		fmt.Fprintf(sb, "# TYPE liveview_connections_rejected_total counter\n")
+		fmt.Fprintf(sb, "liveview_connections_rejected_total %d\n", atomic.LoadUint64(&metricConnectionsReject))
+		metricEventDuration.write(sb, "liveview_event_duration_seconds", "event")
+		metricCommitDuration.write(sb, "liveview_commit_duration_seconds", "component_type")
+		fmt.Fprintf(sb, "# TYPE liveview_commit_bytes_total counter\n")
+		fmt.Fprintf(sb, "liveview_commit_bytes_total %d\n", atomic.LoadUint64(&metricCommitBytesTotal))
+		fmt.Fprintf(sb, "# TYPE liveview_commits_skipped_total counter\n")
+		fmt.Fprintf(sb, "liveview_commits_skipped_total %d\n", atomic.LoadUint64(&metricCommitsSkipped))
+		metricErrorsTotal.write(sb, "liveview_errors_total", "type")
+		metricComponentMemoryBytes.write(sb, "liveview_component_memory_bytes", "component_type")
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write([]byte(sb.String()))
+	})
+}
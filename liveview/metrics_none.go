@@ -0,0 +1,24 @@
+//go:build !metrics
+
+package liveview
+
+import (
+	"net/http"
+	"time"
+)
+
+func metricConnectionOpened()                                   {}
+func metricConnectionClosed()                                   {}
+func metricObserveEvent(name string, start time.Time)           {}
+func metricObserveCommit(id string, start time.Time, bytes int) {}
+func metricError(kind string)                                   {}
+func metricCommitSkipped()                                      {}
+func metricConnectionRejected()                                 {}
+func metricComponentMemory(id string, bytes int64)              {}
+
+const metricsEnabled = false
+
+// MetricsHandler return 404, build with -tags metrics for enable metrics
+func MetricsHandler() http.Handler {
+	return http.NotFoundHandler()
+}
@@ -2,11 +2,14 @@ package liveview
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"sync"
 	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,6 +17,14 @@ import (
 var (
 	componentsDrivers map[string]LiveDriver = make(map[string]LiveDriver)
 	mu                sync.Mutex
+	// ReplayBufferMax is the cap of ComponentDriver.ReplayBuffer
+	ReplayBufferMax int = 100
+	// replayTypes are the types of messages that they can be resent on reconnect without side effects
+	replayTypes = map[string]bool{"fill": true, "text": true, "style": true, "set": true, "propertie": true}
+
+	componentType = reflect.TypeOf((*Component)(nil)).Elem()
+	updaterType   = reflect.TypeOf((*updater)(nil)).Elem()
+	contextType   = reflect.TypeOf((*context.Context)(nil)).Elem()
 )
 
 // Component it is interface for implement one component
@@ -58,6 +69,8 @@ type LiveDriver interface {
 	GetElementById(string) string
 
 	SetData(interface{})
+
+	ForceUpdate()
 }
 
 func (cw *ComponentDriver[T]) SetData(data interface{}) {
@@ -78,11 +91,30 @@ type ComponentDriver[T Component] struct {
 	DriversPage       *map[string]LiveDriver
 	channelIn         *map[string]chan interface{}
 	// Events has rewrite of our implementings of  events, examples click, change, keyup, keydown, etc
-	Events map[string]func(c T, data interface{})
+	Events map[string]func(c T, ctx context.Context, data interface{})
 	Data   interface{}
-}
-
-func (cw *ComponentDriver[T]) SetEvent(name string, fx func(c T, data interface{})) {
+	// EventTimeout is the max duration of one event, after it the ctx of event is cancelled (default 30s, 0 is without timeout)
+	EventTimeout time.Duration
+	// EventError is the error of last event (example timeout), it is available in the template. It is written with muState
+	EventError error
+	// ReplayBuffer is the number of last messages of this component that are resent to the page on reconnect (default 10)
+	ReplayBuffer int
+	// Locale is the locale of {{t "key"}} in the template of component, empty is the locale of SetLocale
+	Locale string
+	// Memo skip Commit when the exported fields of component, Data and EventError did not change (reflect.DeepEqual).
+	// The unexported fields, methods and globals used by the template are not compared, so enable it only when the
+	// template depends of exported fields. A component with the method ShouldUpdate(prev, next interface{}) bool uses it
+	// instead
+	Memo bool
+
+	state       map[string]interface{}
+	forceUpdate bool
+	muState     sync.Mutex
+	shortcuts   map[string]shortcut
+	authHandler func(componentID string, sessionInfo interface{}) bool
+}
+
+func (cw *ComponentDriver[T]) SetEvent(name string, fx func(c T, ctx context.Context, data interface{})) {
 	cw.Events[name] = fx
 }
 
@@ -97,13 +129,34 @@ func (cw *ComponentDriver[T]) Commit() {
 			log.Println("Recovered in Commit:", r)
 		}
 	}()
-	t := template.Must(template.New("component").Funcs(FuncMapTemplate).Parse(cw.Component.GetTemplate()))
+	if !cw.needUpdate() {
+		metricCommitSkipped()
+		return
+	}
+	start := time.Now()
+	t := template.Must(template.New("component").Funcs(FuncMapTemplate).Funcs(cw.templateFuncs()).Parse(cw.Component.GetTemplate()))
 	buf := new(bytes.Buffer)
+	cw.muState.Lock()
 	err := t.Execute(buf, cw.Component)
+	cw.muState.Unlock()
 	if err != nil {
+		metricError("template")
 		log.Println(err)
 	}
+	buf.WriteString(cw.shortcutsHTML())
 	cw.FillValueById(cw.GetID(), buf.String())
+	metricObserveCommit(cw.componentType(), start, buf.Len())
+	cw.publishDebugCommit(start, buf.Len())
+	cw.observeMemory()
+}
+
+// templateFuncs return the functions of template that depend of component
+func (cw *ComponentDriver[T]) templateFuncs() template.FuncMap {
+	locale := cw.Locale
+	if locale == "" {
+		locale = GetLocale()
+	}
+	return templateFuncsLocale(locale)
 }
 
 func (cw *ComponentDriver[T]) StartDriver(drivers *map[string]LiveDriver, channelIn *map[string]chan interface{}, channel chan (map[string]interface{})) {
@@ -114,6 +167,9 @@ func (cw *ComponentDriver[T]) StartDriver(drivers *map[string]LiveDriver, channe
 	}()
 	cw.channel = channel
 	cw.channelIn = channelIn
+	cw.muState.Lock()
+	cw.state = nil
+	cw.muState.Unlock()
 	cw.Component.Start()
 	cw.DriversPage = drivers
 	mu.Lock()
@@ -220,12 +276,19 @@ func NewDriver[T Component](id string, c T) *ComponentDriver[T] {
 func newDriver[T Component](c T) *ComponentDriver[T] {
 	driver := &ComponentDriver[T]{Component: c}
 	driver.componentsDrivers = make(map[string]LiveDriver)
-	driver.Events = make(map[string]func(T, interface{}))
+	driver.Events = make(map[string]func(T, context.Context, interface{}))
+	driver.EventTimeout = 30 * time.Second
+	driver.ReplayBuffer = 10
 	return driver
 }
 
 // ExecuteEvent execute events
 func (cw *ComponentDriver[T]) ExecuteEvent(name string, data interface{}) {
+	cw.executeEvent(context.Background(), name, data)
+}
+
+// executeEvent execute the event with a ctx derived from parent
+func (cw *ComponentDriver[T]) executeEvent(parent context.Context, name string, data interface{}) {
 	if cw == nil {
 		return
 	}
@@ -235,72 +298,172 @@ func (cw *ComponentDriver[T]) ExecuteEvent(name string, data interface{}) {
 			data = make(map[string]interface{})
 		}
 
-		if cw.Events != nil {
-			if fx, ok := cw.Events[name]; ok {
-				go func() {
-					defer HandleReover()
-					fx(cw.Component, data)
-				}()
-				return
-			}
+		// the error of a previous event is not shown after this one
+		cw.muState.Lock()
+		cw.EventError = nil
+		cw.muState.Unlock()
+
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if cw.EventTimeout > 0 {
+			ctx, cancel = context.WithTimeout(parent, cw.EventTimeout)
+		} else {
+			ctx, cancel = context.WithCancel(parent)
 		}
-		func() {
-			defer HandleReoverPass()
-			in := []reflect.Value{reflect.ValueOf(data)}
-			reflect.ValueOf(cw.Component).MethodByName(name).Call(in)
+		defer cancel()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			defer metricObserveEvent(cw.eventLabel(name), time.Now())
+			cw.publishDebugEvent(name, data)
+			cw.dispatchEvent(ctx, name, data)
 		}()
 
+		select {
+		case <-done:
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				metricError("timeout")
+				err := fmt.Errorf("event %s of %s timeout after %s", name, cw.GetIDComponet(), cw.EventTimeout)
+				// the handler is still running and it can Commit, EventError is read with muState
+				cw.muState.Lock()
+				cw.EventError = err
+				cw.muState.Unlock()
+				log.Println(err)
+				cw.Commit()
+			}
+		}
 	}(cw)
 }
 
+func (cw *ComponentDriver[T]) dispatchEvent(ctx context.Context, name string, data interface{}) {
+	if cw.Events != nil {
+		if fx, ok := cw.Events[name]; ok {
+			defer HandleReover()
+			fx(cw.Component, ctx, data)
+			return
+		}
+	}
+	defer HandleReoverPass()
+	method, ok := cw.eventMethod(name)
+	if !ok {
+		log.Printf("event %s of %s is not a method of the component", name, cw.GetIDComponet())
+		return
+	}
+	in := []reflect.Value{reflect.ValueOf(data)}
+	if method.Type().NumIn() == 2 {
+		in = []reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(data)}
+	}
+	method.Call(in)
+}
+
+// eventMethod return the method name of component that the browser can call, the methods of ComponentDriver (promoted
+// by the embed, example EvalScript or Redirect) and of Component are not events
+func (cw *ComponentDriver[T]) eventMethod(name string) (reflect.Value, bool) {
+	if _, ok := reflect.TypeOf(cw).MethodByName(name); ok {
+		return reflect.Value{}, false
+	}
+	for _, t := range []reflect.Type{componentType, updaterType} {
+		if _, ok := t.MethodByName(name); ok {
+			return reflect.Value{}, false
+		}
+	}
+	method := reflect.ValueOf(cw.Component).MethodByName(name)
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+	switch method.Type().NumIn() {
+	case 1:
+		return method, true
+	case 2:
+		return method, method.Type().In(0) == contextType
+	}
+	return reflect.Value{}, false
+}
+
+// componentType return the type of component, it is the label of metrics because the ids of components are not bounded
+func (cw *ComponentDriver[T]) componentType() string {
+	return strings.TrimPrefix(reflect.TypeOf(cw.Component).String(), "*")
+}
+
+// eventLabel return the label of event in metrics, the names sent by the browser that are not events are "unknown"
+func (cw *ComponentDriver[T]) eventLabel(name string) string {
+	if _, ok := cw.Events[name]; !ok {
+		if _, ok := cw.eventMethod(name); !ok {
+			return "unknown"
+		}
+	}
+	return cw.componentType() + "." + name
+}
+
+func (cw *ComponentDriver[T]) send(msg map[string]interface{}) {
+	if _, ok := replayTypes[fmt.Sprint(msg["type"])]; ok {
+		if session := replaySessionOf(cw.channel); session != nil {
+			session.record(cw.GetIDComponet(), cw.ReplayBuffer, msg)
+		}
+	}
+	cw.channel <- msg
+}
+
 // Remove
 func (cw *ComponentDriver[T]) Remove(id string) {
-	cw.channel <- map[string]interface{}{"type": "remove", "id": id}
+	cw.send(map[string]interface{}{"type": "remove", "id": id})
 }
 
 // AddNode add node to id
 func (cw *ComponentDriver[T]) AddNode(id string, value string) {
-	cw.channel <- map[string]interface{}{"type": "addNode", "id": id, "value": value}
+	cw.send(map[string]interface{}{"type": "addNode", "id": id, "value": value})
+}
+
+// AppendHTML execute document.getElementById("$id").insertAdjacentHTML("beforeend", $value)
+func (cw *ComponentDriver[T]) AppendHTML(id string, value string) {
+	cw.send(map[string]interface{}{"type": "append", "id": id, "value": value})
 }
 
 // FillValue is same SetHTML
 func (cw *ComponentDriver[T]) FillValueById(id string, value string) {
-	cw.channel <- map[string]interface{}{"type": "fill", "id": id, "value": value}
+	if id == cw.GetID() || id == cw.GetIDComponet() {
+		cw.invalidateChildren()
+	}
+	cw.send(map[string]interface{}{"type": "fill", "id": id, "value": value})
 }
 
 // FillValue is same SetHTML
 func (cw *ComponentDriver[T]) FillValue(value string) {
-	cw.channel <- map[string]interface{}{"type": "fill", "id": cw.GetIDComponet(), "value": value}
+	cw.invalidateChildren()
+	cw.send(map[string]interface{}{"type": "fill", "id": cw.GetIDComponet(), "value": value})
 }
 
 // SetHTML is same FillValue :p haha, execute  document.getElementById("$id").innerHTML = $value
 func (cw *ComponentDriver[T]) SetHTML(value string) {
-	cw.channel <- map[string]interface{}{"type": "fill", "id": cw.GetIDComponet(), "value": value}
+	cw.invalidateChildren()
+	cw.send(map[string]interface{}{"type": "fill", "id": cw.GetIDComponet(), "value": value})
 }
 
 // SetText execute document.getElementById("$id").innerText = $value
 func (cw *ComponentDriver[T]) SetText(value string) {
-	cw.channel <- map[string]interface{}{"type": "text", "id": cw.GetIDComponet(), "value": value}
+	cw.send(map[string]interface{}{"type": "text", "id": cw.GetIDComponet(), "value": value})
 }
 
 // SetPropertie execute  document.getElementById("$id")[$propertie] = $value
 func (cw *ComponentDriver[T]) SetPropertie(propertie string, value interface{}) {
-	cw.channel <- map[string]interface{}{"type": "propertie", "id": cw.GetIDComponet(), "propertie": propertie, "value": value}
+	cw.send(map[string]interface{}{"type": "propertie", "id": cw.GetIDComponet(), "propertie": propertie, "value": value})
 }
 
 // SetValue execute document.getElementById("$id").value = $value|
 func (cw *ComponentDriver[T]) SetValue(value interface{}) {
-	cw.channel <- map[string]interface{}{"type": "set", "id": cw.GetIDComponet(), "value": value}
+	cw.send(map[string]interface{}{"type": "set", "id": cw.GetIDComponet(), "value": value})
 }
 
 // EvalScript execute eval($code);
 func (cw *ComponentDriver[T]) EvalScript(code string) {
-	cw.channel <- map[string]interface{}{"type": "script", "value": code}
+	cw.send(map[string]interface{}{"type": "script", "value": code})
 }
 
 // SetStyle execute  document.getElementById("$id").style.cssText = $style
 func (cw *ComponentDriver[T]) SetStyle(style string) {
-	cw.channel <- map[string]interface{}{"type": "style", "id": cw.GetIDComponet(), "value": style}
+	cw.send(map[string]interface{}{"type": "style", "id": cw.GetIDComponet(), "value": style})
 }
 
 // GetElementById same as GetValue
@@ -337,7 +500,7 @@ func (cw *ComponentDriver[T]) get(id string, subType string, value string) strin
 	uid := uuid.NewString()
 	(*cw.channelIn)[uid] = make(chan interface{})
 	defer delete((*cw.channelIn), uid)
-	cw.channel <- map[string]interface{}{"type": "get", "id": id, "value": value, "id_ret": uid, "sub_type": subType}
+	cw.send(map[string]interface{}{"type": "get", "id": id, "value": value, "id_ret": uid, "sub_type": subType})
 	data := <-(*cw.channelIn)[uid]
 	if data != nil {
 		return fmt.Sprint(data)
@@ -0,0 +1,115 @@
+package liveview
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type eventTestComponent struct {
+	*ComponentDriver[*eventTestComponent]
+	Clicks  int
+	done    chan error
+	session chan interface{}
+}
+
+func (t *eventTestComponent) GetDriver() LiveDriver { return t }
+func (t *eventTestComponent) Start()                {}
+func (t *eventTestComponent) GetTemplate() string {
+	return `<div id="{{.IdComponent}}">{{.Clicks}} {{if .EventError}}{{.EventError}}{{end}}</div>`
+}
+func (t *eventTestComponent) Click(data interface{}) { t.Clicks++ }
+
+// Slow wait the cancel of ctx
+func (t *eventTestComponent) Slow(ctx context.Context, data interface{}) {
+	<-ctx.Done()
+	t.done <- ctx.Err()
+}
+
+// Who send the sessionInfo of the event
+func (t *eventTestComponent) Who(ctx context.Context, data interface{}) {
+	t.session <- SessionInfo(ctx)
+}
+
+func TestExecuteEventSessionInfo(t *testing.T) {
+	c := &eventTestComponent{session: make(chan interface{}, 2)}
+	driver, _ := newMemoTestDriver("events", c)
+	// two connections share the driver, each event has the sessionInfo of its connection
+	driver.executeEvent(context.WithValue(context.Background(), sessionInfoKey{}, "alice"), "Who", nil)
+	if got := <-c.session; got != "alice" {
+		t.Errorf("SessionInfo = %v, want alice", got)
+	}
+	driver.executeEvent(context.WithValue(context.Background(), sessionInfoKey{}, "bob"), "Who", nil)
+	if got := <-c.session; got != "bob" {
+		t.Errorf("SessionInfo = %v, want bob", got)
+	}
+	driver.ExecuteEvent("Who", nil)
+	if got := <-c.session; got != nil {
+		t.Errorf("SessionInfo without connection = %v, want nil", got)
+	}
+}
+
+func TestDispatchEvent(t *testing.T) {
+	c := &eventTestComponent{}
+	driver, channel := newMemoTestDriver("events", c)
+	driver.dispatchEvent(context.Background(), "Click", map[string]interface{}{})
+	if c.Clicks != 1 {
+		t.Errorf("Click was called %d times, want 1", c.Clicks)
+	}
+	// the methods of driver and of Component can not be called by the browser
+	for _, name := range []string{"EvalScript", "Redirect", "SetTitle", "Commit", "Start", "GetTemplate", "GetDriver", "Unknown"} {
+		driver.dispatchEvent(context.Background(), name, "window.location.href = 'https://evil'")
+		select {
+		case msg := <-channel:
+			t.Errorf("event %s sent %v", name, msg)
+		default:
+		}
+	}
+}
+
+func TestExecuteEventTimeout(t *testing.T) {
+	c := &eventTestComponent{done: make(chan error, 1)}
+	driver, channel := newMemoTestDriver("events", c)
+	driver.EventTimeout = 10 * time.Millisecond
+	driver.ExecuteEvent("Slow", nil)
+	select {
+	case err := <-c.done:
+		if err != context.DeadlineExceeded {
+			t.Errorf("ctx of event was cancelled with %v, want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ctx of event was not cancelled")
+	}
+	select {
+	case msg := <-channel:
+		if msg["type"] != "fill" {
+			t.Errorf("timeout sent %v, want fill", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout did not Commit")
+	}
+	driver.muState.Lock()
+	err := driver.EventError
+	driver.muState.Unlock()
+	if err == nil {
+		t.Error("EventError is nil after the timeout")
+	}
+}
+
+func TestMetricLabels(t *testing.T) {
+	driver, _ := newMemoTestDriver("events", &eventTestComponent{})
+	// the labels do not have the id of component or the names sent by the browser
+	if got := driver.componentType(); got != "liveview.eventTestComponent" {
+		t.Errorf("componentType = %q", got)
+	}
+	tests := map[string]string{
+		"Click":      "liveview.eventTestComponent.Click",
+		"EvalScript": "unknown",
+		"x1234":      "unknown",
+	}
+	for name, want := range tests {
+		if got := driver.eventLabel(name); got != want {
+			t.Errorf("eventLabel(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
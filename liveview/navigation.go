@@ -0,0 +1,68 @@
+package liveview
+
+import (
+	"encoding/json"
+	"log"
+)
+
+// Redirect execute window.location.href = $url
+func (cw *ComponentDriver[T]) Redirect(url string) {
+	cw.send(map[string]interface{}{"type": "redirect", "value": url})
+}
+
+// PushHistoryState add an entry to the browser history with $url (empty keeps the url), when the user goes back to it
+// $eventName (default "HandlePopState") is sent to the component with $state serialized in JSON as data
+func (cw *ComponentDriver[T]) PushHistoryState(eventName string, state interface{}, url string) {
+	cw.sendHistoryState("history_push", eventName, state, url)
+}
+
+// ReplaceHistoryState is PushHistoryState but it replaces the current entry, so it does not add a back-button entry
+func (cw *ComponentDriver[T]) ReplaceHistoryState(eventName string, state interface{}, url string) {
+	cw.sendHistoryState("history_replace", eventName, state, url)
+}
+
+func (cw *ComponentDriver[T]) sendHistoryState(mtype string, eventName string, state interface{}, url string) {
+	if eventName == "" {
+		eventName = "HandlePopState"
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		log.Println("PushHistoryState:", err)
+		return
+	}
+	cw.send(map[string]interface{}{"type": mtype, "id": cw.GetIDComponet(), "event": eventName, "value": string(b), "url": url})
+}
+
+// HandlePopState is the default event of PushHistoryState, the components rewrite it for restore the state of entry
+func (cw *ComponentDriver[T]) HandlePopState(data interface{}) {}
+
+// OpenTab execute window.open($url, '_blank')
+func (cw *ComponentDriver[T]) OpenTab(url string) {
+	cw.send(map[string]interface{}{"type": "open_tab", "value": url})
+}
+
+// ScrollTo execute document.getElementById("$id").scrollIntoView({behavior: $behavior})
+func (cw *ComponentDriver[T]) ScrollTo(elementID string, behavior string) {
+	if behavior == "" {
+		behavior = "auto"
+	}
+	cw.send(map[string]interface{}{"type": "scroll", "id": elementID, "value": behavior})
+}
+
+// FocusElement execute document.getElementById("$id").focus() and select() if selectText is true
+func (cw *ComponentDriver[T]) FocusElement(elementID string, selectText bool) {
+	cw.send(map[string]interface{}{"type": "focus", "id": elementID, "value": selectText})
+}
+
+// DownloadFile download $content as file $filename in the browser with data URI
+func (cw *ComponentDriver[T]) DownloadFile(filename, mimeType, content string) {
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	cw.send(map[string]interface{}{"type": "download", "value": content, "filename": filename, "mime": mimeType})
+}
+
+// CopyToClipboard execute navigator.clipboard.writeText($text), the result is sent as event ClipboardWriteResult
+func (cw *ComponentDriver[T]) CopyToClipboard(text string) {
+	cw.send(map[string]interface{}{"type": "clipboard_write", "id": cw.GetIDComponet(), "value": text})
+}
@@ -0,0 +1,30 @@
+package liveview
+
+import "log"
+
+// BrowserNotification is the notification of browser, if ClickAction is not empty the click send event NotificationClick with ClickAction as data
+type BrowserNotification struct {
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	Icon        string `json:"icon"`
+	ClickAction string `json:"click_action"`
+}
+
+// NotificationPermission return Notification.permission ("granted", "denied", "default" or "" if it is not supported)
+func (cw *ComponentDriver[T]) NotificationPermission() string {
+	return cw.get(cw.GetIDComponet(), "notification_permission", "")
+}
+
+// ShowBrowserNotification show notification of browser if the permission was granted
+func (cw *ComponentDriver[T]) ShowBrowserNotification(title, body, icon string) {
+	cw.SendBrowserNotification(BrowserNotification{Title: title, Body: body, Icon: icon})
+}
+
+// SendBrowserNotification show notification of browser if the permission was granted
+func (cw *ComponentDriver[T]) SendBrowserNotification(notification BrowserNotification) {
+	if permission := cw.NotificationPermission(); permission != "granted" {
+		log.Println("Notification is not allowed:", cw.GetIDComponet(), permission)
+		return
+	}
+	cw.send(map[string]interface{}{"type": "notification", "id": cw.GetIDComponet(), "value": notification})
+}
@@ -2,10 +2,14 @@ package liveview
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"text/template"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
@@ -21,9 +25,68 @@ type PageControl struct {
 	AfterCode string
 	Router    *echo.Echo
 	Debug     bool
+	// MetricsPath path for expose metrics in Prometheus format, empty is disabled (build with -tags metrics)
+	MetricsPath string
+	// EnableCompression enable permessage-deflate in the websocket
+	EnableCompression bool
+	// CompressionThreshold is the min size in bytes of message to compress (default 1024)
+	CompressionThreshold int
+	// CSRFSecret if it is set the websocket require the token of meta csrf-token as first message, the token is bound to the
+	// session id of cookie liveview_session and it is checked before create the components (an expired token reloads
+	// the page)
+	CSRFSecret string
+	// EnableServiceWorker serve /sw.js that cache the wasm and the page for offline
+	EnableServiceWorker bool
+	// ServiceWorkerAssets are other assets precached by the service worker
+	ServiceWorkerAssets []string
+	// OfflineBannerHTML is the html of banner shown when the websocket is disconnected (default "Reconnecting…" with spinner)
+	OfflineBannerHTML string
+	// AuthHandler run in the upgrade of websocket, if it returns error the response is 401. The sessionInfo is passed to the
+	// checks of ComponentDriver.WithAuth
+	AuthHandler func(c echo.Context) (sessionInfo interface{}, err error)
+	// AuthRedirectURL is the url where the browser is redirected when an event is rejected by WithAuth (default "/")
+	AuthRedirectURL string
+	// ShutdownTimeout is the max time that Shutdown wait for the websockets to close (default 30s)
+	ShutdownTimeout time.Duration
+	// EnableHTTP2Push push the wasm and AdditionalPushAssets with the page (HTTP/2), only in the first visit
+	EnableHTTP2Push      bool
+	AdditionalPushAssets []string
+	// PushCacheMaxAge is the time that the browser keeps the pushed assets without push again (default 24h)
+	PushCacheMaxAge time.Duration
+	// EnableAssetFingerprinting add ?v=<sha256> to the urls of assets in the page, so a new deploy invalidates the cache
+	EnableAssetFingerprinting bool
+	// MaxConnections is the max of websockets open, 0 is unlimited
+	MaxConnections int
+	// ConnectionQueueDepth is the number of websockets that wait for a free connection when MaxConnections are open
+	ConnectionQueueDepth int
+	// OnConnectionLimitReached answer the rejected websockets (default 503 with JSON error)
+	OnConnectionLimitReached func(c echo.Context) error
+
+	shuttingDown atomic.Bool
+	connections  sync.WaitGroup
+	clients      map[chan map[string]interface{}]bool
+	muClients    sync.Mutex
+	slots        chan struct{}
+	onceSlots    sync.Once
+	queued       atomic.Int64
+	manifest     map[string]string
+	muManifest   sync.Mutex
+}
+
+type pageData struct {
+	*PageControl
+	CSRFToken string
 }
 
 var (
+	defaultOfflineBannerHTML string = `
+<style>
+	#liveview-offline-banner { position: fixed; top: 0; left: 0; right: 0; z-index: 10000; padding: 8px; text-align: center; background: #333; color: #fff; font-family: sans-serif; }
+	#liveview-offline-banner .liveview-spinner { display: inline-block; width: 12px; height: 12px; margin-right: 8px; border: 2px solid #fff; border-top-color: transparent; border-radius: 50%; animation: liveview-spin 1s linear infinite; vertical-align: middle; }
+	@keyframes liveview-spin { to { transform: rotate(360deg); } }
+</style>
+<span class="liveview-spinner"></span>Reconnecting…`
+
 	templateBase string = `
 <html lang="{{.Lang}}">
 	<head>
@@ -33,23 +96,39 @@ var (
 			{{.Css}}
 		</style>
 		<meta charset="utf-8"/>
+		{{if .CSRFToken}}<meta name="csrf-token" content="{{.CSRFToken}}">{{end}}
+		{{if .EnableServiceWorker}}<meta name="liveview-service-worker" content="/sw.js">{{end}}
         <script src="assets/wasm_exec.js"></script>
 	</head>
     <body>
 		<div id="content"> 
 		</div>
+		<template id="liveview-offline-banner-template">{{.OfflineBannerHTML}}</template>
 		<script>
 		const go = new Go();
 		WebAssembly.instantiateStreaming(fetch("assets/json.wasm"), go.importObject).then((result) => {
 			go.run(result.instance);
 		});
 		</script>
+		{{if .Debug}}<div id="liveview-inspectors" style="position:fixed;top:0;right:0;bottom:0;width:360px;overflow:auto;z-index:9999;background:#fff;border-left:1px solid #ccc;font:12px monospace"></div>{{end}}
 		{{.AfterCode}}
     </body>
 </html>
 `
 )
 
+// writeMessage send data as JSON, it is compressed if EnableCompression and it has CompressionThreshold bytes or more
+func (pc *PageControl) writeMessage(ws *websocket.Conn, data map[string]interface{}) error {
+	msg, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if pc.EnableCompression {
+		ws.EnableWriteCompression(len(msg) >= pc.CompressionThreshold)
+	}
+	return ws.WriteMessage(websocket.TextMessage, msg)
+}
+
 // Register this method to register in router of Echo page and websocket
 func (pc *PageControl) Register(fx func() LiveDriver) {
 	if Exists(pc.AfterCode) {
@@ -61,21 +140,92 @@ func (pc *PageControl) Register(fx func() LiveDriver) {
 	if pc.Lang == "" {
 		pc.Lang = "en"
 	}
+	if pc.OfflineBannerHTML == "" {
+		pc.OfflineBannerHTML = defaultOfflineBannerHTML
+	}
+	if pc.AuthRedirectURL == "" {
+		pc.AuthRedirectURL = "/"
+	}
+	if pc.PushCacheMaxAge == 0 {
+		pc.PushCacheMaxAge = 24 * time.Hour
+	}
+	if pc.CompressionThreshold == 0 {
+		pc.CompressionThreshold = 1024
+	}
 	if Exists("live.js") {
 		pc.LiveJs, _ = FileToString("live.js")
 	}
 
 	pc.Router.Static("/assets", "assets")
+	if pc.EnableAssetFingerprinting {
+		pc.fingerprintAssets()
+	}
+	if pc.EnableServiceWorker {
+		pc.registerServiceWorker()
+	}
+	if pc.Debug {
+		EnableDebugEvents()
+	}
+	if pc.MetricsPath != "" {
+		pc.Router.GET(pc.MetricsPath, echo.WrapHandler(MetricsHandler()))
+	}
 	pc.Router.GET(pc.Path, func(c echo.Context) error {
 		t := template.Must(template.New("page_control").Parse(templateBase))
 		buf := new(bytes.Buffer)
-		_ = t.Execute(buf, pc)
-		c.HTML(http.StatusOK, buf.String())
+		data := pageData{PageControl: pc}
+		sessionID := csrfSession(c, true)
+		if pc.CSRFSecret != "" {
+			data.CSRFToken = NewCSRFToken(pc.CSRFSecret, sessionID, time.Now())
+		}
+		_ = t.Execute(buf, data)
+		if pc.EnableHTTP2Push {
+			pc.pushAssets(c)
+		}
+		c.HTML(http.StatusOK, pc.rewriteAssetURLs(buf.String()))
 
 		return nil
 	})
 
 	pc.Router.GET(pc.Path+"ws_goliveview", func(c echo.Context) error {
+		if pc.shuttingDown.Load() {
+			return c.String(http.StatusServiceUnavailable, "shutting down")
+		}
+		if !pc.acquireConnection(c.Request().Context()) {
+			return pc.rejectConnection(c)
+		}
+		defer pc.releaseConnection()
+		csrfSessionID := csrfSession(c, false)
+		if pc.CSRFSecret != "" && csrfSessionID == "" {
+			return c.String(http.StatusForbidden, "missing session")
+		}
+		var sessionInfo interface{}
+		if pc.AuthHandler != nil {
+			var err error
+			sessionInfo, err = pc.AuthHandler(c)
+			if err != nil {
+				return c.String(http.StatusUnauthorized, err.Error())
+			}
+		}
+
+		upgrader := websocket.Upgrader{EnableCompression: pc.EnableCompression}
+		ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+		if err != nil {
+			return err
+		}
+		defer ws.Close()
+		// the token is checked before create the components, so a rejected socket receives nothing of the page
+		if pc.CSRFSecret != "" && !pc.validateCSRF(ws, csrfSessionID) {
+			fmt.Println("Invalid CSRF token")
+			return nil
+		}
+		channel := make(chan (map[string]interface{}))
+		untrack, ok := pc.trackConnection(channel)
+		if !ok {
+			// Shutdown started after the upgrade, the client reconnects to other server
+			pc.writeMessage(ws, map[string]interface{}{"type": "script", "value": "liveview.prepareShutdown()"})
+			return nil
+		}
+		defer untrack()
 
 		content := fx()
 		defer func() {
@@ -102,17 +252,21 @@ func (pc *PageControl) Register(fx func() LiveDriver) {
 		for _, v := range componentsDrivers {
 			content.Mount(v.GetComponet())
 		}
+		if pc.Debug && NewInspector != nil {
+			for _, v := range componentsDrivers {
+				inspector := NewInspector(v.GetIDComponet(), "liveview-inspectors")
+				content.Mount(inspector)
+				if closer, ok := inspector.(interface{ Close() }); ok {
+					defer closer.Close()
+				}
+			}
+		}
 
 		content.SetID("content")
 		//content.SetIDComponent("content")
 
-		channel := make(chan (map[string]interface{}))
-		upgrader := websocket.Upgrader{}
-		ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
-		if err != nil {
-			return err
-		}
-		defer ws.Close()
+		metricConnectionOpened()
+		defer metricConnectionClosed()
 
 		drivers := make(map[string]LiveDriver)
 		channelIn := make(map[string](chan interface{}))
@@ -125,14 +279,38 @@ func (pc *PageControl) Register(fx func() LiveDriver) {
 		defer func() {
 			end <- true
 		}()
+		// the replay session is bound to the cookie, so other browser can not read the messages of the page
+		replayID := ""
+		if csrfSessionID != "" && c.QueryParam("session") != "" {
+			replayID = csrfSessionID + ":" + c.QueryParam("session")
+		}
+		closeReplay, reopened := openReplaySession(replayID, channel)
 		go func() {
 			defer HandleReover()
 			for {
 				select {
 				case data := <-channel:
-					ws.WriteJSON(data)
+					if err := pc.writeMessage(ws, data); err != nil {
+						fmt.Println(err)
+					}
 				case <-end:
-					return
+					if reopened == nil {
+						return
+					}
+					// the components keep sending to channel until the page reconnects, these messages are
+					// recorded for the replay
+					timeout := time.After(ReplaySessionTTL)
+					for {
+						select {
+						case <-channel:
+						case <-reopened:
+							closeReplay()
+							return
+						case <-timeout:
+							closeReplay()
+							return
+						}
+					}
 				}
 			}
 		}()
@@ -151,11 +329,35 @@ func (pc *PageControl) Register(fx func() LiveDriver) {
 			if mtype, ok := data["type"]; ok {
 				if mtype == "data" {
 					param := data["data"]
-					drivers[data["id"].(string)].ExecuteEvent(data["event"].(string), param)
+					id, _ := data["id"].(string)
+					event, _ := data["event"].(string)
+					driver, ok := drivers[id]
+					if !ok || event == "" {
+						fmt.Println("Unknown event:", data["id"], data["event"])
+						continue
+					}
+					if a, ok := driver.(authorizer); ok && !a.authorize(sessionInfo) {
+						fmt.Println("Unauthorized event:", data["id"], data["event"])
+						redirect, _ := json.Marshal(pc.AuthRedirectURL)
+						channel <- map[string]interface{}{"type": "script", "value": "window.location.href = " + string(redirect)}
+						continue
+					}
+					// the sessionInfo of this connection is in the ctx of event, the driver is shared between connections
+					if e, ok := driver.(sessionExecutor); ok {
+						e.executeEvent(context.WithValue(context.Background(), sessionInfoKey{}, sessionInfo), event, param)
+					} else {
+						driver.ExecuteEvent(event, param)
+					}
 				}
 				if mtype == "get" {
 					param := data["data"]
-					channelIn[data["id_ret"].(string)] <- param
+					idRet, _ := data["id_ret"].(string)
+					if in, ok := channelIn[idRet]; ok {
+						in <- param
+					}
+				}
+				if mtype == "reconnect" {
+					channel <- map[string]interface{}{"type": "replay", "messages": replayMessages(replayID)}
 				}
 			}
 		}
@@ -0,0 +1,48 @@
+package liveview
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// pushCookie mark the browsers that received the assets, they have them in cache and they are not pushed again
+const pushCookie = "liveview_pushed"
+
+var onceWarnPush sync.Once
+
+// pushAssets push wasm_exec.js, json.wasm and AdditionalPushAssets with HTTP/2, with HTTP/1.1 it adds Link preload headers
+func (pc *PageControl) pushAssets(c echo.Context) {
+	if cookie, err := c.Request().Cookie(pushCookie); err == nil && cookie.Value == "1" {
+		return
+	}
+	assets := append([]string{"/assets/wasm_exec.js", "/assets/json.wasm"}, pc.AdditionalPushAssets...)
+	for i, asset := range assets {
+		assets[i] = pc.assetURL(asset)
+	}
+	pusher, ok := c.Response().Writer.(http.Pusher)
+	if !ok {
+		onceWarnPush.Do(func() {
+			fmt.Println("EnableHTTP2Push: HTTP/2 is not available, using Link preload headers")
+		})
+	}
+	for _, asset := range assets {
+		if ok {
+			if err := pusher.Push(asset, nil); err == nil {
+				continue
+			}
+		}
+		as := "fetch"
+		switch path := strings.SplitN(asset, "?", 2)[0]; {
+		case strings.HasSuffix(path, ".js"):
+			as = "script"
+		case strings.HasSuffix(path, ".css"):
+			as = "style"
+		}
+		c.Response().Header().Add("Link", fmt.Sprintf("<%s>; rel=preload; as=%s; crossorigin", asset, as))
+	}
+	http.SetCookie(c.Response(), &http.Cookie{Name: pushCookie, Value: "1", Path: "/", MaxAge: int(pc.PushCacheMaxAge.Seconds()), HttpOnly: true})
+}
@@ -0,0 +1,50 @@
+package liveview
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// benchmarkPage request the page over HTTP/2 (TLS). The Go client disables server push, so with EnableHTTP2Push the
+// server tries the push and sends the Link preload headers, the benchmark measures that cost in the page response
+func benchmarkPage(b *testing.B, push bool) {
+	e := echo.New()
+	pc := &PageControl{Title: "Bench", Path: "/", Router: e, EnableHTTP2Push: push}
+	pc.Register(func() LiveDriver {
+		return NewLayout("bench_push", `<div></div>`)
+	})
+	server := httptest.NewUnstartedServer(e)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+	client := server.Client()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := client.Get(server.URL + "/")
+		if err != nil {
+			b.Fatal(err)
+		}
+		if resp.ProtoMajor != 2 {
+			b.Fatalf("protocol %s, want HTTP/2", resp.Proto)
+		}
+		if push && len(resp.Header.Values("Link")) == 0 {
+			b.Fatal("missing Link preload headers")
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+}
+
+// BenchmarkPageWithoutPush and BenchmarkPageWithPush compare the page response over HTTP/2 with and without
+// EnableHTTP2Push, the time to DOMContentLoaded in a browser is measured with example/example_push
+func BenchmarkPageWithoutPush(b *testing.B) {
+	benchmarkPage(b, false)
+}
+
+func BenchmarkPageWithPush(b *testing.B) {
+	benchmarkPage(b, true)
+}
@@ -4,6 +4,7 @@ import "fmt"
 
 func HandleReover() {
 	if r := recover(); r != nil {
+		metricError("panic")
 		fmt.Println("Recovering from panic:", r)
 	}
 }
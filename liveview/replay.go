@@ -0,0 +1,124 @@
+package liveview
+
+import (
+	"sync"
+	"time"
+)
+
+// ReplaySessionTTL is the time that the messages of a page are kept after its websocket is closed, waiting for the reconnect
+var ReplaySessionTTL = 5 * time.Minute
+
+type replayEntry struct {
+	driver string
+	msg    map[string]interface{}
+}
+
+// replaySession keeps the last messages sent to one page (the session id is generated by the wasm) in the order they
+// were sent, it lives across the websockets of the page
+type replaySession struct {
+	mu       sync.Mutex
+	entries  []replayEntry
+	open     int
+	closedAt time.Time
+	next     chan struct{}
+}
+
+var (
+	replaySessions = make(map[string]*replaySession)
+	replayChannels = make(map[chan map[string]interface{}]*replaySession)
+	muReplay       sync.Mutex
+)
+
+// openReplaySession associate the channel of a websocket with the session id, the returned function must be called
+// when the channel is not used anymore. The returned channel is closed when other websocket opens the session (the page
+// reconnected), it is nil without session
+func openReplaySession(id string, channel chan map[string]interface{}) (func(), <-chan struct{}) {
+	if id == "" {
+		return func() {}, nil
+	}
+	muReplay.Lock()
+	defer muReplay.Unlock()
+	now := time.Now()
+	for key, s := range replaySessions {
+		s.mu.Lock()
+		expired := s.open == 0 && now.Sub(s.closedAt) > ReplaySessionTTL
+		s.mu.Unlock()
+		if expired {
+			delete(replaySessions, key)
+		}
+	}
+	s, ok := replaySessions[id]
+	if !ok {
+		s = &replaySession{}
+		replaySessions[id] = s
+	}
+	s.mu.Lock()
+	s.open++
+	if s.next != nil {
+		close(s.next)
+	}
+	s.next = make(chan struct{})
+	reopened := s.next
+	s.mu.Unlock()
+	replayChannels[channel] = s
+	return func() {
+		muReplay.Lock()
+		delete(replayChannels, channel)
+		muReplay.Unlock()
+		s.mu.Lock()
+		s.open--
+		s.closedAt = time.Now()
+		s.mu.Unlock()
+	}, reopened
+}
+
+func replaySessionOf(channel chan map[string]interface{}) *replaySession {
+	muReplay.Lock()
+	defer muReplay.Unlock()
+	return replayChannels[channel]
+}
+
+// record add msg of driver, only the last size messages of each driver are kept
+func (s *replaySession) record(driver string, size int, msg map[string]interface{}) {
+	if size > ReplayBufferMax {
+		size = ReplayBufferMax
+	}
+	if size <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, replayEntry{driver: driver, msg: msg})
+	count, first := 0, -1
+	for i, e := range s.entries {
+		if e.driver == driver {
+			if first == -1 {
+				first = i
+			}
+			count++
+		}
+	}
+	if count > size {
+		s.entries = append(s.entries[:first], s.entries[first+1:]...)
+	}
+}
+
+// replayMessages return the messages of session in the order they were sent
+func replayMessages(id string) []map[string]interface{} {
+	messages := make([]map[string]interface{}, 0)
+	if id == "" {
+		return messages
+	}
+	muReplay.Lock()
+	s, ok := replaySessions[id]
+	muReplay.Unlock()
+	if !ok {
+		return messages
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.entries {
+		messages = append(messages, e.msg)
+	}
+	return messages
+}
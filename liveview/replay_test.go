@@ -0,0 +1,38 @@
+package liveview
+
+import "testing"
+
+func TestReplaySessionReopened(t *testing.T) {
+	closeNone, none := openReplaySession("", make(chan map[string]interface{}))
+	closeNone()
+	if none != nil {
+		t.Error("openReplaySession without session returned a channel")
+	}
+	first := make(chan map[string]interface{})
+	closeFirst, reopened := openReplaySession("cookie:page", first)
+	replaySessionOf(first).record("a", 10, map[string]interface{}{"type": "fill", "id": "a"})
+	select {
+	case <-reopened:
+		t.Fatal("session is reopened before other websocket")
+	default:
+	}
+	second := make(chan map[string]interface{})
+	closeSecond, _ := openReplaySession("cookie:page", second)
+	defer closeSecond()
+	select {
+	case <-reopened:
+	default:
+		t.Fatal("session is not reopened after other websocket")
+	}
+	closeFirst()
+	if got := len(replayMessages("cookie:page")); got != 1 {
+		t.Errorf("replayMessages has %d messages, want 1", got)
+	}
+	// the session of other cookie and the empty session do not have the messages
+	if got := len(replayMessages("other:page")); got != 0 {
+		t.Errorf("replayMessages of other cookie has %d messages, want 0", got)
+	}
+	if got := len(replayMessages("")); got != 0 {
+		t.Errorf("replayMessages without session has %d messages, want 0", got)
+	}
+}
@@ -0,0 +1,74 @@
+package liveview
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/labstack/echo/v4"
+)
+
+var templateServiceWorker string = `
+const CACHE = "liveview-{{.Version}}";
+const ASSETS = {{.Assets}};
+const SHELL = {{.Shell}};
+const OFFLINE = "<html><body><h3>Offline</h3><p>The application is not available, it will reconnect when the network is back.</p></body></html>";
+
+self.addEventListener("install", (event) => {
+	event.waitUntil(caches.open(CACHE).then((cache) => cache.addAll(ASSETS.concat([SHELL]))).then(() => self.skipWaiting()));
+});
+
+self.addEventListener("activate", (event) => {
+	event.waitUntil(caches.keys().then((keys) => Promise.all(
+		keys.filter((key) => key.startsWith("liveview-") && key !== CACHE).map((key) => caches.delete(key))
+	)).then(() => self.clients.claim()));
+});
+
+self.addEventListener("fetch", (event) => {
+	const request = event.request;
+	if (request.method !== "GET") {
+		return;
+	}
+	if (request.mode === "navigate") {
+		event.respondWith(fetch(request).catch(() => caches.match(SHELL).then((response) =>
+			response || new Response(OFFLINE, { headers: { "Content-Type": "text/html" } })
+		)));
+		return;
+	}
+	event.respondWith(caches.match(request).then((response) => response || fetch(request)));
+});
+`
+
+// registerServiceWorker register /sw.js, the version of cache is the hash of json.wasm
+func (pc *PageControl) registerServiceWorker() {
+	version := "dev"
+	if content, err := FileToString("assets/json.wasm"); err == nil {
+		sum := sha256.Sum256([]byte(content))
+		version = hex.EncodeToString(sum[:])[:12]
+	}
+	assets := append([]string{"assets/wasm_exec.js", "assets/json.wasm"}, pc.ServiceWorkerAssets...)
+	for i, asset := range assets {
+		// with EnableAssetFingerprinting the page requests the urls with hash
+		if !strings.HasPrefix(asset, "/") {
+			assets[i] = strings.TrimPrefix(pc.assetURL("/"+asset), "/")
+		} else {
+			assets[i] = pc.assetURL(asset)
+		}
+	}
+	assetsJson, _ := json.Marshal(assets)
+	shellJson, _ := json.Marshal(pc.Path)
+
+	t := template.Must(template.New("service_worker").Parse(templateServiceWorker))
+	buf := new(bytes.Buffer)
+	_ = t.Execute(buf, map[string]string{"Version": version, "Assets": string(assetsJson), "Shell": string(shellJson)})
+	sw := buf.String()
+
+	pc.Router.GET("/sw.js", func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "no-cache")
+		return c.Blob(http.StatusOK, "application/javascript", []byte(sw))
+	})
+}
@@ -0,0 +1,109 @@
+package liveview
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+type shortcut struct {
+	event  string
+	data   string
+	global bool
+}
+
+// normalizeShortcut return the keys in the order of wasm ctrl+meta+alt+shift+key, so "Shift+Ctrl+Z" is "ctrl+shift+z"
+func normalizeShortcut(keys string) string {
+	modifiers := map[string]bool{}
+	key := ""
+	for _, p := range strings.Split(strings.ToLower(strings.ReplaceAll(keys, " ", "")), "+") {
+		switch p {
+		case "ctrl", "control":
+			modifiers["ctrl"] = true
+		case "meta", "cmd", "command":
+			modifiers["meta"] = true
+		case "alt", "option":
+			modifiers["alt"] = true
+		case "shift":
+			modifiers["shift"] = true
+		default:
+			key = p
+		}
+	}
+	result := ""
+	for _, m := range []string{"ctrl", "meta", "alt", "shift"} {
+		if modifiers[m] {
+			result += m + "+"
+		}
+	}
+	return result + key
+}
+
+// RegisterShortcut send $eventName with $data to the component when $keys are pressed (example "ctrl+z", "shift+delete"),
+// it is not sent when the focus is in input, textarea or [contenteditable]. It is rendered in the next Commit
+func (cw *ComponentDriver[T]) RegisterShortcut(keys string, eventName string, data interface{}) {
+	cw.registerShortcut(keys, eventName, data, false)
+}
+
+// RegisterShortcutGlobal is RegisterShortcut but it is sent with the focus in inputs too
+func (cw *ComponentDriver[T]) RegisterShortcutGlobal(keys string, eventName string, data interface{}) {
+	cw.registerShortcut(keys, eventName, data, true)
+}
+
+// UnregisterShortcut remove the shortcut of $keys in the next Commit
+func (cw *ComponentDriver[T]) UnregisterShortcut(keys string) {
+	cw.muState.Lock()
+	defer cw.muState.Unlock()
+	delete(cw.shortcuts, normalizeShortcut(keys))
+	cw.forceUpdate = true
+}
+
+func (cw *ComponentDriver[T]) registerShortcut(keys string, eventName string, data interface{}, global bool) {
+	value := ""
+	switch v := data.(type) {
+	case nil:
+	case string:
+		value = v
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			value = fmt.Sprint(v)
+		} else {
+			value = string(b)
+		}
+	}
+	cw.muState.Lock()
+	defer cw.muState.Unlock()
+	if cw.shortcuts == nil {
+		cw.shortcuts = make(map[string]shortcut)
+	}
+	cw.shortcuts[normalizeShortcut(keys)] = shortcut{event: eventName, data: value, global: global}
+	cw.forceUpdate = true
+}
+
+// shortcutsHTML return the hidden elements with data-hotkey of shortcuts, the wasm has one keydown listener for all of them
+func (cw *ComponentDriver[T]) shortcutsHTML() string {
+	cw.muState.Lock()
+	defer cw.muState.Unlock()
+	if len(cw.shortcuts) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(cw.shortcuts))
+	for k := range cw.shortcuts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	sb := &strings.Builder{}
+	for _, k := range keys {
+		s := cw.shortcuts[k]
+		fmt.Fprintf(sb, `<span hidden data-hotkey="%s" data-hotkey-component="%s" data-hotkey-event="%s" data-hotkey-data="%s"`,
+			html.EscapeString(k), html.EscapeString(cw.IdComponent), html.EscapeString(s.event), html.EscapeString(s.data))
+		if s.global {
+			sb.WriteString(` data-hotkey-global`)
+		}
+		sb.WriteString(`></span>`)
+	}
+	return sb.String()
+}
@@ -0,0 +1,84 @@
+package liveview
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// EnableGracefulShutdown wait for SIGTERM/SIGINT (or the end of ctx) and then run Shutdown, use it before Router.Start
+func (pc *PageControl) EnableGracefulShutdown(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	go func() {
+		defer signal.Stop(signals)
+		select {
+		case <-signals:
+		case <-ctx.Done():
+		}
+		if err := pc.Shutdown(context.Background()); err != nil {
+			fmt.Println("Shutdown:", err)
+		}
+	}()
+}
+
+// Shutdown reject the new websockets, send liveview.prepareShutdown() to the clients so they reconnect to other server and
+// wait up to ShutdownTimeout for the websockets to close, then it shuts down the Router
+func (pc *PageControl) Shutdown(ctx context.Context) error {
+	if pc.ShutdownTimeout == 0 {
+		pc.ShutdownTimeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, pc.ShutdownTimeout)
+	defer cancel()
+
+	// shuttingDown is set with muClients, so trackConnection does not Add to connections after the Wait
+	pc.muClients.Lock()
+	pc.shuttingDown.Store(true)
+	clients := make([]chan map[string]interface{}, 0, len(pc.clients))
+	for client := range pc.clients {
+		clients = append(clients, client)
+	}
+	pc.muClients.Unlock()
+	for _, client := range clients {
+		select {
+		case client <- map[string]interface{}{"type": "script", "value": "liveview.prepareShutdown()"}:
+		case <-time.After(time.Second):
+		}
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		pc.connections.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		fmt.Println("Shutdown: timeout waiting for websockets")
+	}
+	return pc.Router.Shutdown(ctx)
+}
+
+// trackConnection register the channel of a websocket for Shutdown, the returned function must be called when it is closed.
+// It returns false if Shutdown started, then the websocket must be closed
+func (pc *PageControl) trackConnection(channel chan map[string]interface{}) (func(), bool) {
+	pc.muClients.Lock()
+	defer pc.muClients.Unlock()
+	if pc.shuttingDown.Load() {
+		return nil, false
+	}
+	pc.connections.Add(1)
+	if pc.clients == nil {
+		pc.clients = make(map[chan map[string]interface{}]bool)
+	}
+	pc.clients[channel] = true
+	return func() {
+		pc.muClients.Lock()
+		delete(pc.clients, channel)
+		pc.muClients.Unlock()
+		pc.connections.Done()
+	}, true
+}
@@ -0,0 +1,54 @@
+package liveview
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestShutdownTrackConnection(t *testing.T) {
+	pc := &PageControl{Router: echo.New(), ShutdownTimeout: time.Second}
+	client := make(chan map[string]interface{}, 1)
+	untrack, ok := pc.trackConnection(client)
+	if !ok {
+		t.Fatal("trackConnection rejected a websocket before Shutdown")
+	}
+	// the websockets that open during Shutdown are tracked before it or rejected, never after the Wait
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if untrack, ok := pc.trackConnection(make(chan map[string]interface{}, 1)); ok {
+					untrack()
+				}
+			}
+		}()
+	}
+	go func() {
+		<-client
+		untrack()
+	}()
+	start := time.Now()
+	if err := pc.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	close(stop)
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Shutdown waited %s, want the close of the websocket", elapsed)
+	}
+	if _, ok := pc.trackConnection(make(chan map[string]interface{})); ok {
+		t.Error("trackConnection accepted a websocket after Shutdown")
+	}
+}